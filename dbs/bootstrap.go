@@ -0,0 +1,54 @@
+package dbs
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/OreCast/DataBookkeeping/utils"
+)
+
+// IsEmptyDB checks if database does not contain any DBS tables. The
+// SCHEMA_VERSION table is not taken into account since it is created
+// by schema version checks.
+func IsEmptyDB() (bool, error) {
+	var stm string
+	var args []interface{}
+	if utils.ORACLE {
+		stm = "SELECT COUNT(*) FROM ALL_TABLES WHERE OWNER = :owner AND TABLE_NAME <> 'SCHEMA_VERSION'"
+		args = append(args, DBOWNER)
+	} else if utils.POSTGRES {
+		stm = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = ? AND table_name <> 'schema_version'"
+		args = append(args, DBOWNER)
+	} else {
+		stm = "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND UPPER(name) <> 'SCHEMA_VERSION'"
+	}
+	stm = Rebind(stm)
+	if utils.VERBOSE > 1 {
+		utils.PrintSQL(stm, args, "execute")
+	}
+	var ntables int64
+	if err := DB.QueryRow(stm, args...).Scan(&ntables); err != nil {
+		return false, Error(err, QueryErrorCode, "", "dbs.IsEmptyDB")
+	}
+	return ntables == 0, nil
+}
+
+// InitDB creates DBS schema in empty database and seeds its reference
+// data, e.g. dataset access types. The schema is created by applying all
+// schema migrations of DB backend.
+func InitDB() error {
+	empty, err := IsEmptyDB()
+	if err != nil {
+		return err
+	}
+	if !empty {
+		msg := fmt.Sprintf("%s database is not empty, use -migrate option to update its schema", Backend())
+		return Error(DatabaseErr, MigrationErrorCode, msg, "dbs.InitDB")
+	}
+	log.Printf("initialize empty %s database", Backend())
+	if err := Migrate(-1); err != nil {
+		return err
+	}
+	return GetTestData(context.Background())
+}
@@ -17,13 +17,26 @@ type Buckets struct {
 	BUCKET_ID              int64  `json:"bucket_id"`
 	BUCKET                 string `json:"bucket" validate:"required"`
 	META_ID                string `json:"meta_id" validate:"required"`
-	DATASET_ID             int64  `json:"dataset_id" validate:"required"`
 	CREATION_DATE          int64  `json:"creation_date"`
 	CREATE_BY              string `json:"create_by"`
 	LAST_MODIFICATION_DATE int64  `json:"last_modification_date"`
 	LAST_MODIFIED_BY       string `json:"last_modified_by"`
 }
 
+// bucketFields defines output fields of buckets API along with their SQL columns
+var bucketFields = map[string]string{
+	"bucket_id":              "B.BUCKET_ID",
+	"bucket":                 "B.BUCKET",
+	"meta_id":                "B.META_ID",
+	"creation_date":          "B.CREATION_DATE",
+	"create_by":              "B.CREATE_BY",
+	"last_modification_date": "B.LAST_MODIFICATION_DATE",
+	"last_modified_by":       "B.LAST_MODIFIED_BY",
+}
+
+// bucketSortFields defines output fields of buckets API which can be used for sorting
+var bucketSortFields = []string{"bucket_id", "bucket", "creation_date", "last_modification_date"}
+
 // Buckets DBS API
 //
 //gocyclo:ignore
@@ -32,6 +45,37 @@ func (a *API) GetBucket() error {
 	var conds []string
 	var err error
 
+	if val, ok := a.Params["bucket"]; ok {
+		if val != "" {
+			conds, args = AddParam("bucket", "B.BUCKET", a.Params, conds, args)
+		}
+	}
+	if val, ok := a.Params["dataset"]; ok {
+		if val != "" {
+			// buckets of the dataset are looked up via DATASET_BUCKETS link table
+			var dconds []string
+			dconds, args = AddParam("dataset", "D.DATASET", a.Params, dconds, args)
+			stm := WhereClause(getSQL("select_bucket_datasets"), dconds)
+			conds = append(conds, fmt.Sprintf(" B.BUCKET_ID IN (%s)", stm))
+		}
+	}
+	fields, err := GetFields(a.Params, bucketFields)
+	if err != nil {
+		return err
+	}
+	order, err := GetSort(a.Params, bucketFields, bucketSortFields)
+	if err != nil {
+		return err
+	}
+	var page *Page
+	page, conds, args, err = AddPage("bucket_id", "B.BUCKET_ID", order, a.Params, conds, args)
+	if err != nil {
+		return err
+	}
+	if utils.VERBOSE > 0 {
+		log.Println("### /bucket params", a.Params, conds, args)
+	}
+
 	tmpl := make(Record)
 	tmpl["Owner"] = DBOWNER
 	stm, err := LoadTemplateSQL("select_bucket", tmpl)
@@ -39,10 +83,10 @@ func (a *API) GetBucket() error {
 		return Error(err, LoadErrorCode, "", "dbs.buckets.Buckets")
 	}
 
-	stm = WhereClause(stm, conds)
+	stm = OrderClause(WhereClause(stm, conds), order, page)
 
 	// use generic query API to fetch the results from DB
-	err = executeAll(a.Writer, a.Separator, stm, args...)
+	err = executeAll(a.Context, a.Writer, a.Separator, fields, page, stm, args...)
 	if err != nil {
 		return Error(err, QueryErrorCode, "", "dbs.buckets.Buckets")
 	}
@@ -53,21 +97,116 @@ func (a *API) GetBucket() error {
 func (a *API) InsertBucket() error {
 	// the API provides Reader which will be used by Decode function to load the HTTP payload
 	// and cast it to Buckets data structure
-	return insertRecord(&Buckets{}, a.Reader)
+	return insertRecord(a.Context, &Buckets{}, a.Reader)
 }
 
-// UpdateBucket inserts bucket record in DB
+// UpdateBucket updates bucket record in DB, the attributes provided in
+// HTTP payload overwrite ones of existing bucket record
 func (a *API) UpdateBucket() error {
+	name, err := getSingleValue(a.Params, "bucket")
+	if err != nil {
+		return Error(err, ParametersErrorCode, "", "dbs.buckets.UpdateBucket")
+	}
+	data, err := io.ReadAll(a.Reader)
+	if err != nil {
+		log.Println("fail to read data", err)
+		return Error(err, ReaderErrorCode, "", "dbs.buckets.UpdateBucket")
+	}
+
+	// start transaction
+	tx, err := BeginTx(a.Context)
+	if err != nil {
+		return Error(err, TransactionErrorCode, "", "dbs.buckets.UpdateBucket")
+	}
+	defer tx.Rollback()
+	rec, err := getBucket(tx, name)
+	if err != nil {
+		return err
+	}
+	bid := rec.BUCKET_ID
+	err = json.Unmarshal(data, &rec)
+	if err != nil {
+		log.Println("fail to decode data", err)
+		return Error(err, UnmarshalErrorCode, "", "dbs.buckets.UpdateBucket")
+	}
+	rec.BUCKET_ID = bid
+	rec.LAST_MODIFICATION_DATE = Date()
+	rec.LAST_MODIFIED_BY = a.CreateBy
+	if err = rec.Update(tx); err != nil {
+		return Error(err, UpdateErrorCode, "", "dbs.buckets.UpdateBucket")
+	}
+	err = tx.Commit()
+	if err != nil {
+		return Error(err, CommitErrorCode, "", "dbs.buckets.UpdateBucket")
+	}
 	return nil
 }
 
 // DeleteBucket deletes bucket record in DB
 func (a *API) DeleteBucket() error {
+	name, err := getSingleValue(a.Params, "bucket")
+	if err != nil {
+		return Error(err, ParametersErrorCode, "", "dbs.buckets.DeleteBucket")
+	}
+
+	// start transaction
+	tx, err := BeginTx(a.Context)
+	if err != nil {
+		return Error(err, TransactionErrorCode, "", "dbs.buckets.DeleteBucket")
+	}
+	defer tx.Rollback()
+	rec, err := getBucket(tx, name)
+	if err != nil {
+		return err
+	}
+	// bucket used by datasets is only removed with force flag, in such case
+	// it is unlinked from the datasets
+	force := getBool(a.Params, "force")
+	err = unlinkDatasets(tx, "bucket", name, rec.BUCKET_ID, force, "delete_bucket_datasets")
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(getSQL("delete_bucket"), rec.BUCKET_ID); err != nil {
+		return Error(err, RemoveErrorCode, "", "dbs.buckets.DeleteBucket")
+	}
+	err = tx.Commit()
+	if err != nil {
+		return Error(err, CommitErrorCode, "", "dbs.buckets.DeleteBucket")
+	}
 	return nil
 }
 
+// helper function to fetch bucket record for given bucket name
+func getBucket(tx *Tx, bucket string) (Buckets, error) {
+	var rec Buckets
+	var metaId, createBy, modifiedBy sql.NullString
+	var cdate, mdate sql.NullInt64
+	stm := getSQL("select_bucket_record")
+	err := tx.QueryRow(stm, bucket).Scan(
+		&rec.BUCKET_ID,
+		&rec.BUCKET,
+		&metaId,
+		&cdate,
+		&createBy,
+		&mdate,
+		&modifiedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			msg := fmt.Sprintf("bucket %s does not exist", bucket)
+			return rec, Error(err, GetIDErrorCode, msg, "dbs.buckets.getBucket")
+		}
+		return rec, Error(err, QueryErrorCode, "", "dbs.buckets.getBucket")
+	}
+	rec.META_ID = metaId.String
+	rec.CREATION_DATE = cdate.Int64
+	rec.CREATE_BY = createBy.String
+	rec.LAST_MODIFICATION_DATE = mdate.Int64
+	rec.LAST_MODIFIED_BY = modifiedBy.String
+	return rec, nil
+}
+
 // Insert implementation of Buckets
-func (r *Buckets) Insert(tx *sql.Tx) error {
+func (r *Buckets) Insert(tx *Tx) error {
 	var err error
 	if r.BUCKET_ID == 0 {
 		bucketID, err := getNextId(tx, "BUCKETS", "BUCKET_ID")
@@ -86,17 +225,16 @@ func (r *Buckets) Insert(tx *sql.Tx) error {
 	}
 	// get SQL statement from static area
 	stm := getSQL("insert_bucket")
-	if utils.VERBOSE > 0 {
-		log.Printf("Insert Buckets record %+v", r)
-	} else if utils.VERBOSE > 1 {
+	if utils.VERBOSE > 1 {
 		log.Printf("Insert Buckets\n%s\n%+v", stm, r)
+	} else if utils.VERBOSE > 0 {
+		log.Printf("Insert Buckets record %+v", r)
 	}
-	_, err = tx.Exec(
+	res, err := tx.Exec(
 		stm,
-		r.BUCKET_ID,
+		nullId(r.BUCKET_ID),
 		r.BUCKET,
 		r.META_ID,
-		r.DATASET_ID,
 		r.CREATION_DATE,
 		r.CREATE_BY,
 		r.LAST_MODIFICATION_DATE,
@@ -107,25 +245,54 @@ func (r *Buckets) Insert(tx *sql.Tx) error {
 		}
 		return Error(err, InsertErrorCode, "", "dbs.buckets.Insert")
 	}
-	return nil
+	// obtain id of the record if it was assigned by DB
+	r.BUCKET_ID, err = insertedId(res, r.BUCKET_ID)
+	return err
 }
 
-// Validate implementation of Buckets
-func (r *Buckets) Validate() error {
-	if err := RecordValidator.Struct(*r); err != nil {
-		return DecodeValidatorError(r, err)
+// Update implementation of Buckets
+func (r *Buckets) Update(tx *Tx) error {
+	// set defaults and validate the record
+	r.SetDefaults()
+	err := r.Validate()
+	if err != nil {
+		log.Println("unable to validate record", err)
+		return Error(err, ValidateErrorCode, "", "dbs.buckets.Update")
 	}
-	if matched := unixTimePattern.MatchString(fmt.Sprintf("%d", r.CREATION_DATE)); !matched {
-		msg := "invalid pattern for creation date"
-		return Error(InvalidParamErr, PatternErrorCode, msg, "dbs.buckets.Validate")
+	// get SQL statement from static area
+	stm := getSQL("update_bucket")
+	if utils.VERBOSE > 1 {
+		log.Printf("Update Buckets\n%s\n%+v", stm, r)
+	} else if utils.VERBOSE > 0 {
+		log.Printf("Update Buckets record %+v", r)
 	}
-	if matched := unixTimePattern.MatchString(fmt.Sprintf("%d", r.LAST_MODIFICATION_DATE)); !matched {
-		msg := "invalid pattern for last modification date"
-		return Error(InvalidParamErr, PatternErrorCode, msg, "dbs.buckets.Validate")
+	_, err = tx.Exec(
+		stm,
+		r.BUCKET,
+		r.META_ID,
+		r.LAST_MODIFICATION_DATE,
+		r.LAST_MODIFIED_BY,
+		r.BUCKET_ID)
+	if err != nil {
+		if utils.VERBOSE > 0 {
+			log.Println("unable to update buckets, error", err)
+		}
+		return Error(err, UpdateErrorCode, "", "dbs.buckets.Update")
 	}
 	return nil
 }
 
+// Validate implementation of Buckets
+func (r *Buckets) Validate() error {
+	var errs ValidationErrors
+	errs.AddStruct(*r)
+	errs.AddPattern("bucket", "bucket", r.BUCKET)
+	errs.AddPattern("meta_id", "meta_id", r.META_ID)
+	errs.AddDate("creation_date", r.CREATION_DATE)
+	errs.AddDate("last_modification_date", r.LAST_MODIFICATION_DATE)
+	return validationError(errs, "dbs.buckets.Validate")
+}
+
 // SetDefaults implements set defaults for Buckets
 func (r *Buckets) SetDefaults() {
 	if r.CREATE_BY == "" {
@@ -160,3 +327,119 @@ func (r *Buckets) Decode(reader io.Reader) error {
 	}
 	return nil
 }
+
+// DatasetBuckets represents DatasetBuckets DBS DB table which keeps
+// many-to-many relationship between datasets and buckets
+type DatasetBuckets struct {
+	DATASET_ID    int64  `json:"dataset_id" validate:"required,gt=0"`
+	BUCKET_ID     int64  `json:"bucket_id" validate:"required,gt=0"`
+	CREATION_DATE int64  `json:"creation_date"`
+	CREATE_BY     string `json:"create_by"`
+}
+
+// helper function to add given buckets to the dataset. The buckets which do
+// not exist are created, and existing links of the dataset are preserved.
+func addBuckets(tx *Tx, datasetId int64, buckets []string, metaId, createBy string) error {
+	for _, b := range utils.Set(buckets) {
+		bid, err := GetID(tx, "BUCKETS", "BUCKET_ID", "bucket", b)
+		if err != nil {
+			bucket := Buckets{
+				BUCKET:           b,
+				META_ID:          metaId,
+				CREATE_BY:        createBy,
+				LAST_MODIFIED_BY: createBy,
+			}
+			if err = bucket.Insert(tx); err != nil {
+				return err
+			}
+			bid = bucket.BUCKET_ID
+		}
+		if IfExistMulti(tx, "DATASET_BUCKETS", "BUCKET_ID", []string{"dataset_id", "bucket_id"}, datasetId, bid) {
+			continue
+		}
+		rec := DatasetBuckets{
+			DATASET_ID: datasetId,
+			BUCKET_ID:  bid,
+			CREATE_BY:  createBy,
+		}
+		if err = rec.Insert(tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// helper function to set buckets of given dataset. It replaces existing
+// links of the dataset with provided list of buckets.
+func setBuckets(tx *Tx, datasetId int64, buckets []string, metaId, createBy string) error {
+	stm := getSQL("delete_dataset_buckets")
+	if _, err := tx.Exec(stm, datasetId); err != nil {
+		return Error(err, RemoveErrorCode, "", "dbs.buckets.setBuckets")
+	}
+	return addBuckets(tx, datasetId, buckets, metaId, createBy)
+}
+
+// Insert implementation of DatasetBuckets
+func (r *DatasetBuckets) Insert(tx *Tx) error {
+	// set defaults and validate the record
+	r.SetDefaults()
+	err := r.Validate()
+	if err != nil {
+		log.Println("unable to validate record", err)
+		return Error(err, ValidateErrorCode, "", "dbs.buckets.Insert")
+	}
+	// get SQL statement from static area
+	stm := getSQL("insert_dataset_bucket")
+	if utils.VERBOSE > 1 {
+		log.Printf("Insert DatasetBuckets\n%s\n%+v", stm, r)
+	} else if utils.VERBOSE > 0 {
+		log.Printf("Insert DatasetBuckets record %+v", r)
+	}
+	_, err = tx.Exec(
+		stm,
+		r.DATASET_ID,
+		r.BUCKET_ID,
+		r.CREATION_DATE,
+		r.CREATE_BY)
+	if err != nil {
+		if utils.VERBOSE > 0 {
+			log.Println("unable to insert dataset buckets, error", err)
+		}
+		return Error(err, InsertErrorCode, "", "dbs.buckets.Insert")
+	}
+	return nil
+}
+
+// Validate implementation of DatasetBuckets
+func (r *DatasetBuckets) Validate() error {
+	var errs ValidationErrors
+	errs.AddStruct(*r)
+	errs.AddDate("creation_date", r.CREATION_DATE)
+	return validationError(errs, "dbs.buckets.Validate")
+}
+
+// SetDefaults implements set defaults for DatasetBuckets
+func (r *DatasetBuckets) SetDefaults() {
+	if r.CREATE_BY == "" {
+		r.CREATE_BY = "Server"
+	}
+	if r.CREATION_DATE == 0 {
+		r.CREATION_DATE = Date()
+	}
+}
+
+// Decode implementation for DatasetBuckets
+func (r *DatasetBuckets) Decode(reader io.Reader) error {
+	// init record with given data record
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		log.Println("fail to read data", err)
+		return Error(err, ReaderErrorCode, "", "dbs.buckets.Decode")
+	}
+	err = json.Unmarshal(data, &r)
+	if err != nil {
+		log.Println("fail to decode data", err)
+		return Error(err, UnmarshalErrorCode, "", "dbs.buckets.Decode")
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package dbs
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestBucketAPI tests buckets APIs, buckets used by datasets are only
+// removed with force flag along with their dataset links
+func TestBucketAPI(t *testing.T) {
+	initTestDB(t)
+	insertTestDataset(t, testDataset)
+
+	if err := callRecordAPI((*API).InsertBucket, nil, `{"bucket": "b3", "meta_id": "m3"}`); err != nil {
+		t.Fatal(err)
+	}
+	checkErrorCode(t, callRecordAPI((*API).InsertBucket, nil, `{"bucket": "b4"}`), ValidateErrorCode)
+	names := recordValues(getRecords(t, (*API).GetBucket, nil), "bucket")
+	if !reflect.DeepEqual(names, []string{"b1", "b2", "b3"}) {
+		t.Errorf("wrong buckets %v", names)
+	}
+	names = recordValues(getRecords(t, (*API).GetBucket, Record{"dataset": "/a/b/c"}), "bucket")
+	if !reflect.DeepEqual(names, []string{"b1", "b2"}) {
+		t.Errorf("wrong buckets of dataset %v", names)
+	}
+
+	// update keeps attributes which are not provided in payload
+	err := callRecordAPI((*API).UpdateBucket, Record{"bucket": "b3"}, `{"meta_id": "m4"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := getRecords(t, (*API).GetBucket, Record{"bucket": "b3"})
+	if len(records) != 1 || records[0]["meta_id"] != "m4" || records[0]["last_modified_by"] != "test" {
+		t.Errorf("bucket is not updated: %v", records)
+	}
+	err = callRecordAPI((*API).UpdateBucket, Record{"bucket": "b9"}, `{"meta_id": "m4"}`)
+	checkErrorCode(t, err, GetIDErrorCode)
+
+	checkErrorCode(t, callRecordAPI((*API).DeleteBucket, Record{"bucket": "b1"}, ""), RecordInUseErrorCode)
+	if err := callRecordAPI((*API).DeleteBucket, Record{"bucket": "b3"}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := callRecordAPI((*API).DeleteBucket, Record{"bucket": "b1", "force": "true"}, ""); err != nil {
+		t.Fatal(err)
+	}
+	names = recordValues(getRecords(t, (*API).GetBucket, Record{"dataset": "/a/b/c"}), "bucket")
+	if !reflect.DeepEqual(names, []string{"b2"}) {
+		t.Errorf("removed bucket is kept in dataset %v", names)
+	}
+	checkErrorCode(t, callRecordAPI((*API).DeleteBucket, Record{"bucket": "b1"}, ""), GetIDErrorCode)
+}
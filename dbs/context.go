@@ -0,0 +1,76 @@
+package dbs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ApiTimeout defines default maximum duration of DBS API call
+var ApiTimeout = 5 * time.Minute
+
+// ApiTimeouts defines maximum durations of specific DBS APIs, e.g. file
+var ApiTimeouts = make(map[string]time.Duration)
+
+// ApiTimeoutOf returns maximum duration of given DBS API
+func ApiTimeoutOf(api string) time.Duration {
+	if val, ok := ApiTimeouts[api]; ok {
+		return val
+	}
+	return ApiTimeout
+}
+
+// Tx represents DB transaction bound to the context of DBS API call. All
+// statements executed within transaction are canceled when context is done,
+// e.g. when client disconnects or API timeout is reached.
+type Tx struct {
+	*sql.Tx
+	ctx context.Context
+}
+
+// BeginTx starts new transaction bound to given context
+func BeginTx(ctx context.Context) (*Tx, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	tx, err := DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, ctx: ctx}, nil
+}
+
+// Context returns context of the transaction
+func (tx *Tx) Context() context.Context {
+	return tx.ctx
+}
+
+// Exec executes statement within transaction context
+func (tx *Tx) Exec(query string, args ...any) (sql.Result, error) {
+	return tx.Tx.ExecContext(tx.ctx, query, args...)
+}
+
+// Query executes query within transaction context
+func (tx *Tx) Query(query string, args ...any) (*sql.Rows, error) {
+	return tx.Tx.QueryContext(tx.ctx, query, args...)
+}
+
+// QueryRow executes query which returns at most one row within transaction context
+func (tx *Tx) QueryRow(query string, args ...any) *sql.Row {
+	return tx.Tx.QueryRowContext(tx.ctx, query, args...)
+}
+
+// ContextError converts error of DBS API call into TimeoutErrorCode error
+// if API did not complete within its timeout
+func ContextError(ctx context.Context, api string, err error) error {
+	if err == nil || ctx == nil {
+		return err
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		msg := fmt.Sprintf("%s API did not complete within %v", api, ApiTimeoutOf(api))
+		return Error(ctx.Err(), TimeoutErrorCode, msg, "dbs.ContextError")
+	}
+	return err
+}
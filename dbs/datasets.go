@@ -1,8 +1,10 @@
 package dbs
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -26,15 +28,33 @@ type Datasets struct {
 
 // DatasetRecord represents input dataset record from HTTP request
 type DatasetRecord struct {
-	Dataset    string   `json:"dataset" validate:"required"`
-	Buckets    []string `json:"buckets" validate:"required"`
-	Site       string   `json:"site" validate:"required"`
-	Processing string   `json:"processing" validate:"required"`
-	Parent     string   `json:"parent_dataset" validate:"required"`
-	MetaId     string   `json:"meta_id" validate:"required"`
-	Files      []string `json:"files" validate:"required"`
+	Dataset    string         `json:"dataset" validate:"required"`
+	Buckets    []string       `json:"buckets" validate:"required"`
+	Site       string         `json:"site" validate:"required"`
+	Processing string         `json:"processing" validate:"required"`
+	Parent     ParentDatasets `json:"parent_dataset"`
+	MetaId     string         `json:"meta_id" validate:"required"`
+	Files      []FileRecord   `json:"files" validate:"required"`
 }
 
+// datasetFields defines output fields of datasets API along with their SQL columns
+var datasetFields = map[string]string{
+	"dataset_id":             "D.DATASET_ID",
+	"dataset":                "D.DATASET",
+	"meta_id":                "D.META_ID",
+	"site":                   "S.SITE",
+	"processing":             "PR.PROCESSING",
+	"parent_dataset":         "DPS.PARENT_DATASET",
+	"dataset_access_type":    "DA.DATASET_ACCESS_TYPE",
+	"create_by":              "D.CREATE_BY",
+	"creation_date":          "D.CREATION_DATE",
+	"last_modified_by":       "D.LAST_MODIFIED_BY",
+	"last_modification_date": "D.LAST_MODIFICATION_DATE",
+}
+
+// datasetSortFields defines output fields of datasets API which can be used for sorting
+var datasetSortFields = []string{"dataset_id", "dataset", "site", "processing", "creation_date", "last_modification_date"}
+
 // Datasets API
 //
 //gocyclo:ignore
@@ -46,12 +66,25 @@ func (a *API) GetDataset() error {
 	var conds []string
 	tmpl := make(Record)
 	tmpl["Owner"] = DBOWNER
+	tmpl["ParentDatasets"] = listAgg("PD.DATASET")
 
 	if val, ok := a.Params["dataset"]; ok {
 		if val != "" {
 			conds, args = AddParam("dataset", "D.DATASET", a.Params, conds, args)
 		}
 	}
+	fields, err := GetFields(a.Params, datasetFields)
+	if err != nil {
+		return err
+	}
+	order, err := GetSort(a.Params, datasetFields, datasetSortFields)
+	if err != nil {
+		return err
+	}
+	page, conds, args, err := AddPage("dataset_id", "D.DATASET_ID", order, a.Params, conds, args)
+	if err != nil {
+		return err
+	}
 	if utils.VERBOSE > 0 {
 		log.Println("### /dataset params", a.Params, conds, args)
 	}
@@ -62,31 +95,35 @@ func (a *API) GetDataset() error {
 		return Error(err, LoadErrorCode, "", "dbs.datasets.Datasets")
 	}
 	cols := []string{
+		"dataset_id",
 		"dataset",
 		"meta_id",
 		"site",
 		"processing",
-		"parent",
+		"parent_dataset",
+		"dataset_access_type",
 		"create_by",
 		"creation_date",
 		"last_modified_by",
 		"last_modification_date",
 	}
 	vals := []interface{}{
+		new(sql.NullInt64),   // dataset_id
 		new(sql.NullString),  // dataset
 		new(sql.NullString),  // meta_id
 		new(sql.NullString),  // site
 		new(sql.NullString),  // processing
-		new(sql.NullString),  // parent
+		new(ParentDatasets),  // parent_dataset
+		new(sql.NullString),  // dataset_access_type
 		new(sql.NullString),  // create_by
 		new(sql.NullFloat64), // creation_date
 		new(sql.NullString),  // last_modified_by
 		new(sql.NullFloat64), // last_modification_date
 	}
-	stm = WhereClause(stm, conds)
+	stm = OrderClause(WhereClause(stm, conds), order, page)
 
 	// use generic query API to fetch the results from DB
-	err = execute(a.Writer, a.Separator, stm, cols, vals, args...)
+	err = execute(a.Context, a.Writer, a.Separator, fields, page, stm, cols, vals, args...)
 	if err != nil {
 		return Error(err, QueryErrorCode, "", "dbs.datasets.Datasets")
 	}
@@ -126,7 +163,7 @@ func (a *API) InsertDataset() error {
 		CREATE_BY:        a.CreateBy,
 		LAST_MODIFIED_BY: a.CreateBy,
 	}
-	err = insertParts(&rec, &record)
+	err = insertParts(a.Context, &rec, &record)
 	if err != nil {
 		return Error(err, CommitErrorCode, "", "dbs.insertRecord")
 	}
@@ -134,9 +171,9 @@ func (a *API) InsertDataset() error {
 }
 
 // helper function to insert parts of the dataset relationships
-func insertParts(rec *DatasetRecord, record *Datasets) error {
+func insertParts(ctx context.Context, rec *DatasetRecord, record *Datasets) error {
 	// start transaction
-	tx, err := DB.Begin()
+	tx, err := BeginTx(ctx)
 	if err != nil {
 		return Error(err, TransactionErrorCode, "", "dbs.insertRecord")
 	}
@@ -171,15 +208,20 @@ func insertParts(rec *DatasetRecord, record *Datasets) error {
 	}
 	record.PROCESSING_ID = processingId
 
-	// insert parent info
-	parentId, err = GetID(tx, "PARENTS", "PARENT_ID", "parent", rec.Parent)
+	// insert parent info, the PARENTS table keeps first parent of the dataset
+	// while full dataset lineage is stored in DATASET_PARENTS table
+	var parentName string
+	if len(rec.Parent) > 0 {
+		parentName = rec.Parent[0]
+	}
+	parentId, err = GetID(tx, "PARENTS", "PARENT_ID", "parent", parentName)
 	if err != nil {
-		if rec.Parent != "" {
-			parent := Parents{PARENT: rec.Parent}
+		if parentName != "" {
+			parent := Parents{PARENT: parentName}
 			if err = parent.Insert(tx); err != nil {
 				return err
 			}
-			parentId, err = GetID(tx, "PARENTS", "PARENT_ID", "parent", rec.Parent)
+			parentId, err = GetID(tx, "PARENTS", "PARENT_ID", "parent", parentName)
 			if err != nil {
 				return err
 			}
@@ -200,31 +242,29 @@ func insertParts(rec *DatasetRecord, record *Datasets) error {
 		if err != nil {
 			return err
 		}
+		// insert dataset lineage
+		err = setParents(tx, datasetId, rec.Dataset, rec.Parent, record.CREATE_BY)
+		if err != nil {
+			return err
+		}
 	}
 
-	// insert all buckets
-	for _, b := range rec.Buckets {
-		bucket := Buckets{
-			BUCKET:     b,
-			DATASET_ID: datasetId,
-			META_ID:    rec.MetaId,
-		}
-		if err = bucket.Insert(tx); err != nil {
-			log.Printf("Bucket %+v already exist", bucket)
-		}
+	// insert all buckets and link them to the dataset
+	err = addBuckets(tx, datasetId, rec.Buckets, rec.MetaId, record.CREATE_BY)
+	if err != nil {
+		return err
 	}
 
-	// insert all files
+	// insert all files, existing files are skipped since failed insert
+	// aborts transaction on some DB backends
 	for _, f := range rec.Files {
-		file := Files{
-			LOGICAL_FILE_NAME: f,
-			DATASET_ID:        datasetId,
-			META_ID:           rec.MetaId,
-			CREATE_BY:         record.CREATE_BY,
-			LAST_MODIFIED_BY:  record.CREATE_BY,
+		if IfExist(tx, "FILES", "FILE_ID", "logical_file_name", f.LogicalFileName) {
+			log.Printf("File %s already exist", f.LogicalFileName)
+			continue
 		}
+		file := f.Record(datasetId, rec.MetaId, record.CREATE_BY)
 		if err = file.Insert(tx); err != nil {
-			log.Printf("File %+v already exist", file)
+			return err
 		}
 	}
 
@@ -233,29 +273,397 @@ func insertParts(rec *DatasetRecord, record *Datasets) error {
 	return err
 }
 
+// UpdateDataset updates dataset record using JSON merge-patch (RFC 7386) payload.
+// The patch may carry site, processing, parent_dataset, meta_id and buckets
+// attributes along with add_files and remove_files lists. The parent_dataset
+// may be either a single dataset name or list of dataset names. A null value of
+// parent_dataset or buckets removes dataset parents or buckets, respectively.
 func (a *API) UpdateDataset() error {
+	dataset, err := getSingleValue(a.Params, "dataset")
+	if err != nil {
+		return Error(err, ParametersErrorCode, "", "dbs.datasets.UpdateDataset")
+	}
+	data, err := io.ReadAll(a.Reader)
+	if err != nil {
+		log.Println("fail to read data", err)
+		return Error(err, ReaderErrorCode, "", "dbs.datasets.UpdateDataset")
+	}
+	patch := make(map[string]json.RawMessage)
+	err = json.Unmarshal(data, &patch)
+	if err != nil {
+		log.Println("fail to decode data", err)
+		return Error(err, UnmarshalErrorCode, "", "dbs.datasets.UpdateDataset")
+	}
+	if utils.VERBOSE > 0 {
+		log.Printf("### update dataset %s with patch %s", dataset, string(data))
+	}
+	err = updateParts(a.Context, dataset, patch, a.CreateBy)
+	if err != nil {
+		return Error(err, UpdateErrorCode, "", "dbs.datasets.UpdateDataset")
+	}
+	// return updated dataset record back to the client
+	return a.GetDataset()
+}
+
+// datasetPatchKeys represents list of keys allowed in dataset merge-patch
+var datasetPatchKeys = []string{
+	"dataset",
+	"site",
+	"processing",
+	"parent_dataset",
+	"meta_id",
+	"buckets",
+	"add_files",
+	"remove_files",
+}
+
+// helper function to decode value of given key from merge-patch record.
+// It returns true if key is present in a patch, and null flag if its value is null.
+func patchValue(patch map[string]json.RawMessage, key string, val any) (bool, bool, error) {
+	raw, ok := patch[key]
+	if !ok {
+		return false, false, nil
+	}
+	if string(raw) == "null" {
+		return true, true, nil
+	}
+	if err := json.Unmarshal(raw, val); err != nil {
+		msg := fmt.Sprintf("unable to decode '%s' value of dataset patch", key)
+		return true, false, Error(err, UnmarshalErrorCode, msg, "dbs.datasets.patchValue")
+	}
+	return true, false, nil
+}
+
+// helper function to fetch dataset record for given dataset name
+func getDataset(tx *Tx, dataset string) (Datasets, error) {
+	var rec Datasets
+	var metaId, createBy, modifiedBy sql.NullString
+	var siteId, processingId, parentId, cdate, mdate sql.NullInt64
+	stm := getSQL("select_dataset_record")
+	err := tx.QueryRow(stm, dataset).Scan(
+		&rec.DATASET_ID,
+		&rec.DATASET,
+		&metaId,
+		&siteId,
+		&processingId,
+		&parentId,
+		&cdate,
+		&createBy,
+		&mdate,
+		&modifiedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			msg := fmt.Sprintf("dataset %s does not exist", dataset)
+			return rec, Error(err, DatasetDoesNotExist, msg, "dbs.datasets.getDataset")
+		}
+		return rec, Error(err, QueryErrorCode, "", "dbs.datasets.getDataset")
+	}
+	rec.META_ID = metaId.String
+	rec.SITE_ID = siteId.Int64
+	rec.PROCESSING_ID = processingId.Int64
+	rec.PARENT_ID = parentId.Int64
+	rec.CREATION_DATE = cdate.Int64
+	rec.CREATE_BY = createBy.String
+	rec.LAST_MODIFICATION_DATE = mdate.Int64
+	rec.LAST_MODIFIED_BY = modifiedBy.String
+	return rec, nil
+}
+
+// helper function to update parts of the dataset relationships
+//
+//gocyclo:ignore
+func updateParts(ctx context.Context, dataset string, patch map[string]json.RawMessage, modifiedBy string) error {
+	for key := range patch {
+		if !utils.InList(key, datasetPatchKeys) {
+			msg := fmt.Sprintf("unsupported key '%s' in dataset patch", key)
+			return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.datasets.updateParts")
+		}
+	}
+
+	// start transaction
+	tx, err := BeginTx(ctx)
+	if err != nil {
+		return Error(err, TransactionErrorCode, "", "dbs.datasets.updateParts")
+	}
+	defer tx.Rollback()
+
+	record, err := getDataset(tx, dataset)
+	if err != nil {
+		return err
+	}
+
+	// dataset name is used as identifier and can't be changed
+	var name string
+	if _, _, err = patchValue(patch, "dataset", &name); err != nil {
+		return err
+	}
+	if name != "" && name != dataset {
+		msg := fmt.Sprintf("dataset name %s does not match %s", name, dataset)
+		return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.datasets.updateParts")
+	}
+
+	// update meta_id
+	var metaId string
+	ok, null, err := patchValue(patch, "meta_id", &metaId)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if null || metaId == "" {
+			msg := "meta_id can't be removed from dataset"
+			return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.datasets.updateParts")
+		}
+		record.META_ID = metaId
+	}
+
+	// update site info
+	var site string
+	ok, null, err = patchValue(patch, "site", &site)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if null || site == "" {
+			msg := "site can't be removed from dataset"
+			return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.datasets.updateParts")
+		}
+		rec := Sites{SITE: site, CREATE_BY: modifiedBy, LAST_MODIFIED_BY: modifiedBy}
+		record.SITE_ID, err = GetRecID(tx, &rec, "SITES", "SITE_ID", "site", site)
+		if err != nil {
+			return err
+		}
+	}
+
+	// update processing info
+	var processing string
+	ok, null, err = patchValue(patch, "processing", &processing)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if null || processing == "" {
+			msg := "processing can't be removed from dataset"
+			return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.datasets.updateParts")
+		}
+		rec := Processing{PROCESSING: processing, CREATE_BY: modifiedBy, LAST_MODIFIED_BY: modifiedBy}
+		record.PROCESSING_ID, err = GetRecID(
+			tx, &rec, "PROCESSING", "PROCESSING_ID", "processing", processing)
+		if err != nil {
+			return err
+		}
+	}
+
+	// update parent info
+	var parents ParentDatasets
+	ok, _, err = patchValue(patch, "parent_dataset", &parents)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if len(parents) == 0 {
+			record.PARENT_ID = 0
+		} else {
+			parent := parents[0]
+			rec := Parents{PARENT: parent, CREATE_BY: modifiedBy, LAST_MODIFIED_BY: modifiedBy}
+			record.PARENT_ID, err = GetRecID(tx, &rec, "PARENTS", "PARENT_ID", "parent", parent)
+			if err != nil {
+				return err
+			}
+		}
+		err = setParents(tx, record.DATASET_ID, dataset, parents, modifiedBy)
+		if err != nil {
+			return err
+		}
+	}
+
+	// update dataset record itself
+	record.LAST_MODIFICATION_DATE = Date()
+	record.LAST_MODIFIED_BY = modifiedBy
+	if err = record.Update(tx); err != nil {
+		return err
+	}
+
+	// replace all buckets
+	var buckets []string
+	ok, _, err = patchValue(patch, "buckets", &buckets)
+	if err != nil {
+		return err
+	}
+	if ok {
+		err = setBuckets(tx, record.DATASET_ID, buckets, record.META_ID, modifiedBy)
+		if err != nil {
+			return err
+		}
+	}
+
+	// remove requested files
+	var removeFiles []string
+	if _, _, err = patchValue(patch, "remove_files", &removeFiles); err != nil {
+		return err
+	}
+	for _, f := range removeFiles {
+		stm := getSQL("delete_dataset_file")
+		res, err := tx.Exec(stm, f, record.DATASET_ID)
+		if err != nil {
+			return Error(err, RemoveErrorCode, "", "dbs.datasets.updateParts")
+		}
+		if nrows, err := res.RowsAffected(); err == nil && nrows == 0 {
+			msg := fmt.Sprintf("file %s does not exist in dataset %s", f, dataset)
+			return Error(InvalidParamErr, FileDoesNotExist, msg, "dbs.datasets.updateParts")
+		}
+	}
+
+	// add new files, files which already belong to the dataset are skipped
+	var addFiles []FileRecord
+	if _, _, err = patchValue(patch, "add_files", &addFiles); err != nil {
+		return err
+	}
+	for _, f := range addFiles {
+		file, err := getFile(tx, f.LogicalFileName)
+		if err == nil {
+			if file.DATASET_ID == record.DATASET_ID {
+				log.Printf("File %s already exist in dataset %s", f.LogicalFileName, dataset)
+				continue
+			}
+			msg := fmt.Sprintf("file %s belongs to another dataset", f.LogicalFileName)
+			return Error(InvalidRequestErr, InvalidRequestErrorCode, msg, "dbs.datasets.updateParts")
+		}
+		var dbsError *DBSError
+		if !errors.As(err, &dbsError) || dbsError.Code != FileDoesNotExist {
+			return err
+		}
+		file = f.Record(record.DATASET_ID, record.META_ID, modifiedBy)
+		if err = file.Insert(tx); err != nil {
+			return err
+		}
+	}
+
+	// datasets left without files are marked as invalid
+	if len(removeFiles) > 0 {
+		if _, err = invalidateEmptyDatasets(tx, []int64{record.DATASET_ID}, modifiedBy); err != nil {
+			return err
+		}
+	}
+
+	// commit all transactions
+	err = tx.Commit()
+	if err != nil {
+		return Error(err, CommitErrorCode, "", "dbs.datasets.updateParts")
+	}
 	return nil
 }
+
+// DatasetDeleteReport represents list of rows removed by DeleteDataset API,
+// or rows which would be removed when API is called in dry-run mode
+type DatasetDeleteReport struct {
+	Dataset  string   `json:"dataset"`
+	DryRun   bool     `json:"dry_run"`
+	Force    bool     `json:"force"`
+	Files    []string `json:"files"`
+	Buckets  []string `json:"buckets"`
+	Children []string `json:"children"`
+}
+
+// DeleteDataset deletes dataset record along with its files and links to
+// its buckets, the bucket records themselves are kept as they may be shared
+// with other datasets.
+// The deletion is refused if dataset is a parent of other datasets unless
+// force parameter is provided, and dry_run parameter only reports rows
+// which would be removed.
 func (a *API) DeleteDataset() error {
+	dataset, err := getSingleValue(a.Params, "dataset")
+	if err != nil {
+		return Error(err, ParametersErrorCode, "", "dbs.datasets.DeleteDataset")
+	}
+	report := DatasetDeleteReport{
+		Dataset: dataset,
+		DryRun:  getBool(a.Params, "dry_run"),
+		Force:   getBool(a.Params, "force"),
+	}
+	err = deleteParts(a.Context, &report)
+	if err != nil {
+		return Error(err, RemoveErrorCode, "", "dbs.datasets.DeleteDataset")
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		return Error(err, MarshalErrorCode, "", "dbs.datasets.DeleteDataset")
+	}
+	a.Writer.Write(data)
+	return nil
+}
+
+// helper function to delete dataset along with its files and bucket links
+func deleteParts(ctx context.Context, report *DatasetDeleteReport) error {
+	// start transaction
+	tx, err := BeginTx(ctx)
+	if err != nil {
+		return Error(err, TransactionErrorCode, "", "dbs.datasets.deleteParts")
+	}
+	defer tx.Rollback()
+
+	record, err := getDataset(tx, report.Dataset)
+	if err != nil {
+		return err
+	}
+
+	// collect all rows which depend on our dataset
+	report.Children, err = queryStrings(tx, getSQL("select_dataset_children"), report.Dataset)
+	if err != nil {
+		return err
+	}
+	report.Files, err = queryStrings(tx, getSQL("select_dataset_files"), record.DATASET_ID)
+	if err != nil {
+		return err
+	}
+	report.Buckets, err = queryStrings(tx, getSQL("select_dataset_buckets"), record.DATASET_ID)
+	if err != nil {
+		return err
+	}
+	if report.DryRun {
+		return nil
+	}
+	if len(report.Children) > 0 && !report.Force {
+		msg := fmt.Sprintf(
+			"dataset %s is a parent of %v, use force flag to delete it",
+			report.Dataset, report.Children)
+		return Error(InvalidRequestErr, DatasetHasChildren, msg, "dbs.datasets.deleteParts")
+	}
+
+	// remove dataset and its dependencies
+	stms := []string{
+		"delete_dataset_files",
+		"delete_dataset_buckets",
+		"delete_lineage_by_child",
+		"delete_lineage_by_parent",
+		"delete_dataset",
+	}
+	for _, key := range stms {
+		stm := getSQL(key)
+		if utils.VERBOSE > 0 {
+			log.Printf("Delete Datasets\n%s\n%+v", stm, record.DATASET_ID)
+		}
+		if _, err = tx.Exec(stm, record.DATASET_ID); err != nil {
+			return Error(err, RemoveErrorCode, "", "dbs.datasets.deleteParts")
+		}
+	}
+
+	// commit all transactions
+	err = tx.Commit()
+	if err != nil {
+		return Error(err, CommitErrorCode, "", "dbs.datasets.deleteParts")
+	}
 	return nil
 }
 
 // Insert implementation of Datasets
-func (r *Datasets) Insert(tx *sql.Tx) error {
-	var tid int64
+func (r *Datasets) Insert(tx *Tx) error {
 	var err error
 	if r.DATASET_ID == 0 {
-		if DBOWNER == "sqlite" {
-			tid, err = LastInsertID(tx, "DATASETS", "dataset_id")
-			r.DATASET_ID = tid + 1
-		} else {
-			tid, err = IncrementSequence(tx, "SEQ_DS")
-			r.DATASET_ID = tid
+		r.DATASET_ID, err = getNextId(tx, "DATASETS", "DATASET_ID")
+		if err != nil {
+			return Error(err, LastInsertErrorCode, "", "dbs.datasets.Insert")
 		}
 	}
-	if err != nil {
-		return Error(err, LastInsertErrorCode, "", "dbs.datasets.Insert")
-	}
 	// set defaults and validate the record
 	r.SetDefaults()
 	err = r.Validate()
@@ -269,14 +677,14 @@ func (r *Datasets) Insert(tx *sql.Tx) error {
 		log.Printf("Insert Datasets\n%s\n%+v", stm, r)
 	}
 	// make final SQL statement to insert dataset record
-	_, err = tx.Exec(
+	res, err := tx.Exec(
 		stm,
-		r.DATASET_ID,
+		nullId(r.DATASET_ID),
 		r.DATASET,
 		r.META_ID,
 		r.SITE_ID,
 		r.PROCESSING_ID,
-		r.PARENT_ID,
+		nullId(r.PARENT_ID),
 		r.CREATION_DATE,
 		r.CREATE_BY,
 		r.LAST_MODIFICATION_DATE,
@@ -287,6 +695,38 @@ func (r *Datasets) Insert(tx *sql.Tx) error {
 		}
 		return Error(err, InsertErrorCode, "", "dbs.datasets.Insert")
 	}
+	// obtain id of the record if it was assigned by DB
+	r.DATASET_ID, err = insertedId(res, r.DATASET_ID)
+	return err
+}
+
+// Update implementation of Datasets
+func (r *Datasets) Update(tx *Tx) error {
+	err := r.Validate()
+	if err != nil {
+		log.Println("unable to validate record", err)
+		return Error(err, ValidateErrorCode, "", "dbs.datasets.Update")
+	}
+	// get SQL statement from static area
+	stm := getSQL("update_dataset")
+	if utils.VERBOSE > 0 {
+		log.Printf("Update Datasets\n%s\n%+v", stm, r)
+	}
+	_, err = tx.Exec(
+		stm,
+		r.META_ID,
+		r.SITE_ID,
+		r.PROCESSING_ID,
+		nullId(r.PARENT_ID),
+		r.LAST_MODIFICATION_DATE,
+		r.LAST_MODIFIED_BY,
+		r.DATASET_ID)
+	if err != nil {
+		if utils.VERBOSE > 0 {
+			log.Printf("unable to update Datasets %+v", err)
+		}
+		return Error(err, UpdateErrorCode, "", "dbs.datasets.Update")
+	}
 	return nil
 }
 
@@ -294,30 +734,30 @@ func (r *Datasets) Insert(tx *sql.Tx) error {
 //
 //gocyclo:ignore
 func (r *Datasets) Validate() error {
-	if err := CheckPattern("dataset", r.DATASET); err != nil {
-		return Error(err, PatternErrorCode, "", "dbs.datasets.Validate")
-	}
-	if matched := unixTimePattern.MatchString(fmt.Sprintf("%d", r.CREATION_DATE)); !matched {
-		msg := "invalid pattern for creation date"
-		return Error(InvalidParamErr, PatternErrorCode, msg, "dbs.datasets.Validate")
+	var errs ValidationErrors
+	if r.DATASET == "" {
+		errs.Add("dataset", r.DATASET, "required")
+	} else {
+		errs.AddPattern("dataset", "dataset", r.DATASET)
 	}
+	errs.AddPattern("meta_id", "meta_id", r.META_ID)
 	if r.CREATION_DATE == 0 {
-		msg := "missing creation_date"
-		return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.datasets.Validate")
+		errs.Add("creation_date", r.CREATION_DATE, "required")
+	} else {
+		errs.AddDate("creation_date", r.CREATION_DATE)
 	}
 	if r.CREATE_BY == "" {
-		msg := "missing create_by"
-		return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.datasets.Validate")
+		errs.Add("create_by", r.CREATE_BY, "required")
 	}
 	if r.LAST_MODIFICATION_DATE == 0 {
-		msg := "missing last_modification_date"
-		return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.datasets.Validate")
+		errs.Add("last_modification_date", r.LAST_MODIFICATION_DATE, "required")
+	} else {
+		errs.AddDate("last_modification_date", r.LAST_MODIFICATION_DATE)
 	}
 	if r.LAST_MODIFIED_BY == "" {
-		msg := "missing last_modified_by"
-		return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.datasets.Validate")
+		errs.Add("last_modified_by", r.LAST_MODIFIED_BY, "required")
 	}
-	return nil
+	return validationError(errs, "dbs.datasets.Validate")
 }
 
 // SetDefaults implements set defaults for Datasets
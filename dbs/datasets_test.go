@@ -0,0 +1,208 @@
+package dbs
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// test dataset record used by datasets tests
+var testDataset = `{
+  "dataset": "/a/b/c",
+  "buckets": ["b1", "b2"],
+  "site": "s1",
+  "processing": "p1",
+  "meta_id": "m1",
+  "files": ["/a/f1", "/a/f2"]
+}`
+
+// helper function to update dataset via UpdateDataset API
+func updateTestDataset(dataset, patch string) error {
+	api, _ := testApi(Record{"dataset": dataset}, patch)
+	return api.UpdateDataset()
+}
+
+// helper function to count lineage links of given dataset
+func countParents(t *testing.T, dataset string) int {
+	t.Helper()
+	var count int
+	stm := `SELECT COUNT(*) FROM DATASET_PARENTS DP
+	JOIN DATASETS D ON D.DATASET_ID = DP.THIS_DATASET_ID WHERE D.DATASET = ?`
+	if err := DB.QueryRow(stm, dataset).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+// TestUpdateDataset tests dataset update via JSON merge-patch
+func TestUpdateDataset(t *testing.T) {
+	initTestDB(t)
+	insertTestDataset(t, testDataset)
+	insertTestDataset(t, `{"dataset": "/x/y/z", "buckets": [], "site": "s1",
+		"processing": "p1", "meta_id": "m1", "files": []}`)
+
+	patch := `{"site": "s2", "meta_id": "m2", "buckets": ["b3"],
+		"parent_dataset": "/x/y/z", "add_files": ["/a/f3"], "remove_files": ["/a/f1"]}`
+	if err := updateTestDataset("/a/b/c", patch); err != nil {
+		t.Fatal(err)
+	}
+	records := getRecords(t, (*API).GetDataset, Record{"dataset": "/a/b/c"})
+	if len(records) != 1 {
+		t.Fatalf("expected single dataset, got %v", records)
+	}
+	rec := records[0]
+	if rec["site"] != "s2" || rec["meta_id"] != "m2" || rec["processing"] != "p1" {
+		t.Errorf("dataset is not patched: %v", rec)
+	}
+	buckets := recordValues(getRecords(t, (*API).GetBucket, Record{"dataset": "/a/b/c"}), "bucket")
+	if !reflect.DeepEqual(buckets, []string{"b3"}) {
+		t.Errorf("wrong dataset buckets %v", buckets)
+	}
+	files := recordValues(getRecords(t, (*API).GetFile, Record{"dataset": "/a/b/c"}), "logical_file_name")
+	if !reflect.DeepEqual(files, []string{"/a/f2", "/a/f3"}) {
+		t.Errorf("wrong dataset files %v", files)
+	}
+	if n := countParents(t, "/a/b/c"); n != 1 {
+		t.Errorf("expected single dataset parent, got %d", n)
+	}
+
+	// null values remove dataset buckets and parents
+	if err := updateTestDataset("/a/b/c", `{"buckets": null, "parent_dataset": null}`); err != nil {
+		t.Fatal(err)
+	}
+	buckets = recordValues(getRecords(t, (*API).GetBucket, Record{"dataset": "/a/b/c"}), "bucket")
+	if len(buckets) != 0 {
+		t.Errorf("dataset buckets are not removed: %v", buckets)
+	}
+	if n := countParents(t, "/a/b/c"); n != 0 {
+		t.Errorf("dataset parents are not removed, found %d", n)
+	}
+
+	// files which already belong to the dataset are skipped
+	if err := updateTestDataset("/a/b/c", `{"add_files": ["/a/f2", "/a/f4"]}`); err != nil {
+		t.Fatal(err)
+	}
+	files = recordValues(getRecords(t, (*API).GetFile, Record{"dataset": "/a/b/c"}), "logical_file_name")
+	if !reflect.DeepEqual(files, []string{"/a/f2", "/a/f3", "/a/f4"}) {
+		t.Errorf("wrong dataset files %v", files)
+	}
+
+	// removal of all dataset files invalidates the dataset
+	patch = `{"remove_files": ["/a/f2", "/a/f3", "/a/f4"]}`
+	if err := updateTestDataset("/a/b/c", patch); err != nil {
+		t.Fatal(err)
+	}
+	records = getRecords(t, (*API).GetDataset, Record{"dataset": "/a/b/c"})
+	if records[0]["dataset_access_type"] != "INVALID" {
+		t.Errorf("empty dataset is not invalidated: %v", records)
+	}
+}
+
+// TestUpdateDatasetErrors tests rejected dataset patches
+func TestUpdateDatasetErrors(t *testing.T) {
+	initTestDB(t)
+	insertTestDataset(t, testDataset)
+	insertTestDataset(t, `{"dataset": "/x/y/z", "buckets": [], "site": "s1",
+		"processing": "p1", "meta_id": "m1", "files": ["/x/f1"]}`)
+
+	checkErrorCode(t, updateTestDataset("/a/b/c", `{"bogus": 1}`), ParametersErrorCode)
+	checkErrorCode(t, updateTestDataset("/a/b/c", `{"meta_id": null}`), ParametersErrorCode)
+	checkErrorCode(t, updateTestDataset("/a/b/c", `{"site": ""}`), ParametersErrorCode)
+	checkErrorCode(t, updateTestDataset("/a/b/c", `{"dataset": "/x/y/z"}`), ParametersErrorCode)
+	checkErrorCode(t, updateTestDataset("/a/b/c", `{"buckets": "b1"}`), UnmarshalErrorCode)
+	checkErrorCode(t, updateTestDataset("/x/y/w", `{"site": "s2"}`), DatasetDoesNotExist)
+	checkErrorCode(t, updateTestDataset("/a/b/c", `{"site": "s2", "remove_files": ["/a/f9"]}`), FileDoesNotExist)
+	checkErrorCode(t, updateTestDataset("/a/b/c", `{"site": "s2", "add_files": ["/x/f1"]}`), InvalidRequestErrorCode)
+
+	// rejected patch should not modify dataset
+	records := getRecords(t, (*API).GetDataset, Record{"dataset": "/a/b/c"})
+	if len(records) != 1 || records[0]["site"] != "s1" || records[0]["meta_id"] != "m1" {
+		t.Errorf("dataset is modified by rejected patch: %v", records)
+	}
+}
+
+// helper function to delete dataset via DeleteDataset API
+func deleteTestDataset(t *testing.T, params Record) (DatasetDeleteReport, error) {
+	t.Helper()
+	var report DatasetDeleteReport
+	api, w := testApi(params, "")
+	if err := api.DeleteDataset(); err != nil {
+		return report, err
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	return report, nil
+}
+
+// TestDeleteDataset tests dataset deletion in dry-run and force modes
+func TestDeleteDataset(t *testing.T) {
+	initTestDB(t)
+	insertTestDataset(t, testDataset)
+	insertTestDataset(t, `{"dataset": "/a/b/d", "buckets": ["b1"], "site": "s1",
+		"processing": "p1", "meta_id": "m1", "parent_dataset": "/a/b/c", "files": []}`)
+
+	// dry-run reports rows of the dataset without removing them
+	report, err := deleteTestDataset(t, Record{"dataset": "/a/b/c", "dry_run": "true"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.DryRun ||
+		!reflect.DeepEqual(report.Files, []string{"/a/f1", "/a/f2"}) ||
+		!reflect.DeepEqual(report.Buckets, []string{"b1", "b2"}) ||
+		!reflect.DeepEqual(report.Children, []string{"/a/b/d"}) {
+		t.Errorf("wrong dry-run report %+v", report)
+	}
+	if records := getRecords(t, (*API).GetDataset, Record{"dataset": "/a/b/c"}); len(records) != 1 {
+		t.Errorf("dataset is removed in dry-run mode")
+	}
+
+	// parent dataset is only removed with force flag
+	_, err = deleteTestDataset(t, Record{"dataset": "/a/b/c"})
+	checkErrorCode(t, err, DatasetHasChildren)
+	report, err = deleteTestDataset(t, Record{"dataset": "/a/b/c", "force": "true"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.DryRun || !report.Force {
+		t.Errorf("wrong delete report %+v", report)
+	}
+	if records := getRecords(t, (*API).GetDataset, Record{"dataset": "/a/b/c"}); len(records) != 0 {
+		t.Errorf("dataset is not removed: %v", records)
+	}
+	files := getRecords(t, (*API).GetFile, Record{"logical_file_name": "/a/f1"})
+	if len(files) != 0 {
+		t.Errorf("dataset files are not removed: %v", files)
+	}
+	// shared bucket is kept along with child dataset which lost its parent
+	buckets := recordValues(getRecords(t, (*API).GetBucket, Record{"dataset": "/a/b/d"}), "bucket")
+	if !reflect.DeepEqual(buckets, []string{"b1"}) {
+		t.Errorf("wrong buckets of remaining dataset %v", buckets)
+	}
+	if n := countParents(t, "/a/b/d"); n != 0 {
+		t.Errorf("lineage of removed dataset is kept, found %d links", n)
+	}
+
+	_, err = deleteTestDataset(t, Record{"dataset": "/a/b/c"})
+	checkErrorCode(t, err, DatasetDoesNotExist)
+}
+
+// TestInsertDatasetFiles tests that existing files are skipped on dataset
+// insert while invalid files fail the whole insert
+func TestInsertDatasetFiles(t *testing.T) {
+	initTestDB(t)
+	insertTestDataset(t, testDataset)
+	insertTestDataset(t, `{"dataset": "/a/b/c", "buckets": [], "site": "s1",
+		"processing": "p1", "meta_id": "m1", "files": ["/a/f2", "/a/f3"]}`)
+	files := recordValues(getRecords(t, (*API).GetFile, Record{"dataset": "/a/b/c"}), "logical_file_name")
+	if !reflect.DeepEqual(files, []string{"/a/f1", "/a/f2", "/a/f3"}) {
+		t.Errorf("wrong dataset files %v", files)
+	}
+
+	api, _ := testApi(nil, `{"dataset": "/a/b/d", "buckets": [], "site": "s1",
+		"processing": "p1", "meta_id": "m1", "files": [{"logical_file_name": "/a/f4", "md5": "xyz"}]}`)
+	checkErrorCode(t, api.InsertDataset(), ValidateErrorCode)
+	if records := getRecords(t, (*API).GetDataset, Record{"dataset": "/a/b/d"}); len(records) != 0 {
+		t.Errorf("dataset with invalid files is inserted: %v", records)
+	}
+}
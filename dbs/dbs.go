@@ -83,7 +83,7 @@ var ConcurrentBulkBlocks bool
 // Each DBS API represents specific Table in back-end DB. And, each individual
 // DBS API implements logic for its own DB records
 type DBRecord interface {
-	Insert(tx *sql.Tx) error  // used to insert given record to DB
+	Insert(tx *Tx) error      // used to insert given record to DB
 	Validate() error          // used to validate given record
 	SetDefaults()             // used to set proper defaults for given record
 	Decode(r io.Reader) error // used to decode given record
@@ -93,20 +93,10 @@ type DBRecord interface {
 // of DBRecord validation errors
 func DecodeValidatorError(r, err interface{}) error {
 	if err != nil {
-		msg := fmt.Sprintf("DBS structure")
-		d, e := json.MarshalIndent(r, "", "   ")
-		if e == nil {
-			msg = fmt.Sprintf("%s JSON representation\n%v", msg, string(d))
-		} else {
-			msg = fmt.Sprintf("%s\n%+v", msg, r)
-		}
-		for _, err := range err.(validator.ValidationErrors) {
-			msg = fmt.Sprintf(
-				"%s\nkey=%v type=%v value=%v, constrain %v %v",
-				msg, err.Field(), err.Type(), err.Value(), err.ActualTag(), err.Param())
-		}
-		log.Println(msg)
-		return Error(ValidationErr, DecodeErrorCode, "", "dbs.DecodeValidatorError")
+		var errs ValidationErrors
+		errs.AddStruct(r)
+		log.Printf("DBS structure %+v fails validation: %v", r, errs)
+		return validationError(errs, "dbs.DecodeValidatorError")
 	}
 	return nil
 }
@@ -117,7 +107,7 @@ func Date() int64 {
 }
 
 // helper function to insert DB record with given reader
-func insertRecord(rec DBRecord, r io.Reader) error {
+func insertRecord(ctx context.Context, rec DBRecord, r io.Reader) error {
 	err := rec.Decode(r)
 	if err != nil {
 		msg := fmt.Sprintf("fail to decode record")
@@ -129,7 +119,7 @@ func insertRecord(rec DBRecord, r io.Reader) error {
 	}
 
 	// start transaction
-	tx, err := DB.Begin()
+	tx, err := BeginTx(ctx)
 	if err != nil {
 		return Error(err, TransactionErrorCode, "", "dbs.insertRecord")
 	}
@@ -159,53 +149,96 @@ func insertRecord(rec DBRecord, r io.Reader) error {
 
 // LoadTemplateSQL function loads DBS SQL templated statements
 func LoadTemplateSQL(tmpl string, tmplData Record) (string, error) {
-	sdir := fmt.Sprintf("%s/sql", utils.STATICDIR)
 	if !strings.HasSuffix(tmpl, ".sql") {
 		tmpl += ".sql"
 	}
 	if utils.VERBOSE > 1 {
 		log.Println("load template", tmpl)
 	}
-	stm, err := utils.ParseTmpl(sdir, tmpl, tmplData)
+	stm, err := utils.ParseStaticTmpl("sql", tmpl, tmplData)
 	if err != nil {
 		return "", Error(err, LoadErrorCode, "", "dbs.LoadTemplateSQL")
 	}
 	if owner, ok := tmplData["Owner"]; ok && owner == "sqlite" {
 		stm = strings.Replace(stm, "sqlite.", "", -1)
 	}
-	if DBOWNER == "sqlite" {
+	// PostgreSQL positional binds are assigned by Rebind function once
+	// statement is fully constructed
+	if DBOWNER == "sqlite" || utils.POSTGRES {
 		stm = utils.ReplaceBinds(stm)
 	}
 	return stm, nil
 }
 
-// LoadSQL function loads DBS SQL statements with Owner
+// LoadSQL function loads DBS SQL statements with Owner. All templates are
+// validated and the failures are reported at once.
 func LoadSQL(owner string) Record {
+	dbsql, err := loadSQL(owner)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return dbsql
+}
+
+// helper function to load all DBS SQL statements with given Owner
+func loadSQL(owner string) (Record, error) {
 	tmplData := make(Record)
 	tmplData["Owner"] = owner
-	sdir := fmt.Sprintf("%s/sql", utils.STATICDIR)
 	if utils.VERBOSE > 1 {
-		log.Println("sql area", sdir)
+		log.Println("sql area", utils.STATICDIR)
+	}
+	files, err := utils.ListStaticFiles("sql")
+	if err != nil {
+		return nil, Error(err, LoadErrorCode, "unable to read sql area", "dbs.loadSQL")
 	}
 	dbsql := make(Record)
-	for _, f := range utils.ListFiles(sdir) {
+	var failures []string
+	for _, f := range files {
+		if !strings.HasSuffix(f, ".sql") {
+			continue
+		}
 		k := strings.Split(f, ".")[0]
-		stm, err := utils.ParseTmpl(sdir, f, tmplData)
+		stm, err := utils.ParseStaticTmpl("sql", f, tmplData)
 		if err != nil {
-			log.Fatal("unable to parse template", err)
+			failures = append(failures, fmt.Sprintf("%s: %v", f, err))
+			continue
 		}
-		if owner, ok := tmplData["Owner"]; ok && owner == "sqlite" {
+		if owner == "sqlite" {
 			stm = strings.Replace(stm, "sqlite.", "", -1)
 		}
 		dbsql[k] = stm
 	}
-	return dbsql
+	if len(failures) > 0 {
+		msg := fmt.Sprintf(
+			"unable to parse %d SQL template(s) for owner %s: %s",
+			len(failures), owner, strings.Join(failures, "; "))
+		return dbsql, Error(InvalidParamErr, LoadErrorCode, msg, "dbs.loadSQL")
+	}
+	return dbsql, nil
+}
+
+// Close closes DBS database. For SQLite the content of write-ahead log is
+// checkpointed into the database file before closing it.
+func Close() error {
+	if DB == nil {
+		return nil
+	}
+	if DBTYPE == "sqlite3" {
+		if _, err := DB.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+			log.Println("unable to checkpoint SQLite WAL", err)
+		}
+	}
+	if err := DB.Close(); err != nil {
+		return Error(err, DatabaseErrorCode, "unable to close database", "dbs.Close")
+	}
+	return nil
 }
 
 // GetTestData executes simple query to ensure that connection to DB is valid.
 // So far we can ask for a data tier id of specific tier since this table
-// is very small and query execution will be really fast.
-func GetTestData() error {
+// is very small and query execution will be really fast. The query is
+// canceled when given context is done.
+func GetTestData(ctx context.Context) error {
 	tmpl := make(Record)
 	tmpl["Owner"] = DBOWNER
 	var args []interface{}
@@ -214,10 +247,11 @@ func GetTestData() error {
 	if err != nil {
 		return Error(err, LoadErrorCode, "", "dbs.GetTestData")
 	}
+	stm = Rebind(stm)
 	if utils.VERBOSE > 1 {
 		utils.PrintSQL(stm, args, "execute")
 	}
-	tx, err := DB.Begin()
+	tx, err := BeginTx(ctx)
 	if err != nil {
 		return Error(err, TransactionErrorCode, "", "dbs.GetTestData")
 	}
@@ -244,12 +278,26 @@ func getSQL(key string) string {
 	// use generic query API to fetch the results from DB
 	val, ok := DBSQL[key]
 	if !ok {
-		msg := fmt.Sprintf("Unable to load %s SQL", key)
-		log.Fatal(msg)
+		// all statements are validated at startup, so we should not kill
+		// the server if statement is missing, instead the query will fail
+		log.Printf("ERROR: unable to load %s SQL", key)
+		return ""
 	}
 	stm := val.(string)
 	if DBOWNER == "sqlite" {
 		stm = utils.ReplaceBinds(stm)
+	} else if utils.POSTGRES {
+		stm = utils.PositionalBinds(stm)
+	}
+	return stm
+}
+
+// Rebind converts binds of given statement to the form used by underlying DB.
+// So far only PostgreSQL requires positional binds, for other DBs the
+// statement is returned as is.
+func Rebind(stm string) string {
+	if utils.POSTGRES {
+		return utils.PositionalBinds(stm)
 	}
 	return stm
 }
@@ -286,6 +334,72 @@ func getSingleValue(params Record, key string) (string, error) {
 	return "", Error(InvalidParamErr, ParseErrorCode, msg, "dbs.getSingleValue")
 }
 
+// helper function to get boolean value from a record, e.g. ?dry_run=true
+func getBool(params Record, key string) bool {
+	val, err := getSingleValue(params, key)
+	if err != nil {
+		return false
+	}
+	flag, err := strconv.ParseBool(val)
+	if err != nil {
+		return false
+	}
+	return flag
+}
+
+// helper function to check that record used by datasets can be removed.
+// Such record is only removed with force flag, and it is unlinked from the
+// datasets via given SQL statement. The records required by datasets, i.e.
+// without unlink statement, can not be removed while datasets use them.
+func unlinkDatasets(tx *Tx, table, name string, rid int64, force bool, unlink string) error {
+	var ndatasets int64
+	err := tx.QueryRow(getSQL("count_"+table+"_datasets"), rid).Scan(&ndatasets)
+	if err != nil {
+		return Error(err, QueryErrorCode, "", "dbs.unlinkDatasets")
+	}
+	if ndatasets == 0 {
+		return nil
+	}
+	if !force {
+		msg := fmt.Sprintf("%s %s is used by %d dataset(s), use force flag to delete it", table, name, ndatasets)
+		return Error(InvalidRequestErr, RecordInUseErrorCode, msg, "dbs.unlinkDatasets")
+	}
+	if unlink == "" {
+		msg := fmt.Sprintf("%s %s is required by %d dataset(s) and can not be unlinked", table, name, ndatasets)
+		return Error(InvalidRequestErr, RecordInUseErrorCode, msg, "dbs.unlinkDatasets")
+	}
+	if _, err = tx.Exec(getSQL(unlink), rid); err != nil {
+		return Error(err, RemoveErrorCode, "", "dbs.unlinkDatasets")
+	}
+	return nil
+}
+
+// helper function to query list of string values for given statement
+func queryStrings(tx *Tx, stm string, args ...interface{}) ([]string, error) {
+	out := []string{}
+	if utils.VERBOSE > 1 {
+		utils.PrintSQL(stm, args, "execute")
+	}
+	rows, err := tx.Query(stm, args...)
+	if err != nil {
+		msg := fmt.Sprintf("unable to query statement: %v", stm)
+		log.Println(msg)
+		return out, Error(err, QueryErrorCode, "", "dbs.queryStrings")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var val sql.NullString
+		if err := rows.Scan(&val); err != nil {
+			return out, Error(err, RowsScanErrorCode, "", "dbs.queryStrings")
+		}
+		out = append(out, val.String)
+	}
+	if err = rows.Err(); err != nil {
+		return out, Error(err, RowsScanErrorCode, "", "dbs.queryStrings")
+	}
+	return out, nil
+}
+
 // WhereClause function construct proper SQL statement from given statement and list of conditions
 func WhereClause(stm string, conds []string) string {
 	if len(conds) == 0 {
@@ -313,11 +427,20 @@ func ParseDBFile(dbfile string) (string, string, string) {
 func placeholder(pholder string) string {
 	if DBTYPE == "ora" || DBTYPE == "oci8" {
 		return fmt.Sprintf(":%s", pholder)
-	} else if DBTYPE == "PostgreSQL" {
-		return fmt.Sprintf("$%s", pholder)
-	} else {
-		return "?"
 	}
+	// PostgreSQL positional binds are assigned by Rebind function
+	return "?"
+}
+
+// helper function to get SQL expression which aggregates values of given
+// column into a single comma separated string
+func listAgg(col string) string {
+	if utils.ORACLE {
+		return fmt.Sprintf("LISTAGG(%s, ',') WITHIN GROUP (ORDER BY %s)", col, col)
+	} else if utils.POSTGRES {
+		return fmt.Sprintf("STRING_AGG(%s, ',' ORDER BY %s)", col, col)
+	}
+	return fmt.Sprintf("GROUP_CONCAT(%s, ',')", col)
 }
 
 // helper function to generate error record
@@ -351,8 +474,8 @@ func CleanStatement(stm string) string {
 // to writer)
 //
 //gocyclo:ignore
-func executeAll(w io.Writer, sep, stm string, args ...interface{}) error {
-	stm = CleanStatement(stm)
+func executeAll(ctx context.Context, w io.Writer, sep string, fields []string, page *Page, stm string, args ...interface{}) error {
+	stm = Rebind(CleanStatement(stm))
 	if DRYRUN {
 		utils.PrintSQL(stm, args, "")
 		return nil
@@ -366,7 +489,7 @@ func executeAll(w io.Writer, sep, stm string, args ...interface{}) error {
 	}
 
 	// execute transaction
-	tx, err := DB.Begin()
+	tx, err := BeginTx(ctx)
 	if err != nil {
 		return Error(err, TransactionErrorCode, "", "dbs.executeAll")
 	}
@@ -398,10 +521,6 @@ func executeAll(w io.Writer, sep, stm string, args ...interface{}) error {
 		if err != nil {
 			return Error(err, RowsScanErrorCode, "", "dbs.executeAll")
 		}
-		if rowCount != 0 && w != nil {
-			// add separator line to our output
-			w.Write([]byte(sep))
-		}
 		// store results into generic record (a dict)
 		rec := make(Record)
 		for i, col := range columns {
@@ -430,10 +549,25 @@ func executeAll(w io.Writer, sep, stm string, args ...interface{}) error {
 				if e == nil {
 					rec[cols[i]] = v
 				}
+			case *ParentDatasets:
+				// copy scanned list since vals are reused for every row
+				rec[cols[i]] = append([]string{}, (*val)...)
 			default:
 				rec[cols[i]] = val
 			}
 		}
+		if page != nil {
+			// paginated records are written once the page is complete
+			if !page.Add(rec, fields) {
+				break
+			}
+			continue
+		}
+		rec = projectRecord(rec, fields)
+		if rowCount != 0 && w != nil {
+			// add separator line to our output
+			w.Write([]byte(sep))
+		}
 		if w != nil {
 			if rowCount == 0 {
 				if sep != "" {
@@ -452,6 +586,9 @@ func executeAll(w io.Writer, sep, stm string, args ...interface{}) error {
 	if err = rows.Err(); err != nil {
 		return Error(err, RowsScanErrorCode, "", "dbs.executeAll")
 	}
+	if page != nil {
+		return page.Write(w, sep)
+	}
 	// make sure we write proper response if no result written
 	if sep != "" && !writtenResults {
 		w.Write([]byte("[]"))
@@ -463,12 +600,16 @@ func executeAll(w io.Writer, sep, stm string, args ...interface{}) error {
 //
 //gocyclo:ignore
 func execute(
+	ctx context.Context,
 	w io.Writer,
-	sep, stm string,
+	sep string,
+	fields []string,
+	page *Page,
+	stm string,
 	cols []string,
 	vals []interface{}, args ...interface{}) error {
 
-	stm = CleanStatement(stm)
+	stm = Rebind(CleanStatement(stm))
 	if DRYRUN {
 		utils.PrintSQL(stm, args, "")
 		return nil
@@ -482,7 +623,7 @@ func execute(
 	}
 
 	// execute transaction
-	tx, err := DB.Begin()
+	tx, err := BeginTx(ctx)
 	if err != nil {
 		return Error(err, TransactionErrorCode, "", "dbs.execute")
 	}
@@ -505,10 +646,6 @@ func execute(
 			log.Println(msg)
 			return Error(err, RowsScanErrorCode, "", "dbs.execute")
 		}
-		if rowCount != 0 && w != nil {
-			// add separator line to our output
-			w.Write([]byte(sep))
-		}
 		rec := make(Record)
 		for i := range cols {
 			vvv := vals[i]
@@ -537,6 +674,18 @@ func execute(
 				rec[cols[i]] = val
 			}
 		}
+		if page != nil {
+			// paginated records are written once the page is complete
+			if !page.Add(rec, fields) {
+				break
+			}
+			continue
+		}
+		rec = projectRecord(rec, fields)
+		if rowCount != 0 && w != nil {
+			// add separator line to our output
+			w.Write([]byte(sep))
+		}
 		if w != nil {
 			if rowCount == 0 {
 				if sep != "" {
@@ -555,6 +704,9 @@ func execute(
 	if err = rows.Err(); err != nil {
 		return Error(err, RowsScanErrorCode, "", "dbs.execute")
 	}
+	if page != nil {
+		return page.Write(w, sep)
+	}
 	// make sure we write proper response if no result written
 	if sep != "" && !writtenResults {
 		w.Write([]byte("[]"))
@@ -563,7 +715,7 @@ func execute(
 }
 
 // helper function to execute sessions
-func executeSessions(tx *sql.Tx, sessions []string) error {
+func executeSessions(tx *Tx, sessions []string) error {
 	// sessions should be executed only for ORACLE backend
 	if !utils.ORACLE {
 		return nil
@@ -587,6 +739,7 @@ func QueryRow(table, id, attr string, val interface{}) (int64, error) {
 	} else {
 		stm = fmt.Sprintf("SELECT T.%s FROM %s.%s T WHERE T.%s = :%s", id, DBOWNER, table, attr, attr)
 	}
+	stm = Rebind(stm)
 	if utils.VERBOSE > 1 {
 		log.Printf("QueryRow\n%s; binding value=%+v", stm, val)
 	}
@@ -603,13 +756,14 @@ func QueryRow(table, id, attr string, val interface{}) (int64, error) {
 }
 
 // GetID function fetches table primary id for a given value
-func GetID(tx *sql.Tx, table, id, attr string, val ...interface{}) (int64, error) {
+func GetID(tx *Tx, table, id, attr string, val ...interface{}) (int64, error) {
 	var stm string
 	if DBOWNER == "sqlite" {
 		stm = fmt.Sprintf("SELECT %s FROM %s WHERE %s = ?", id, table, attr)
 	} else {
 		stm = fmt.Sprintf("SELECT T.%s FROM %s.%s T WHERE T.%s = :%s", id, DBOWNER, table, attr, attr)
 	}
+	stm = Rebind(stm)
 	if utils.VERBOSE > 1 {
 		log.Printf("getID\n%s; binding value=%+v", stm, val)
 	}
@@ -626,7 +780,7 @@ func GetID(tx *sql.Tx, table, id, attr string, val ...interface{}) (int64, error
 }
 
 // GetRecID function fetches table primary id for a given value and insert it if necessary
-func GetRecID(tx *sql.Tx, rec DBRecord, table, id, attr string, val ...interface{}) (int64, error) {
+func GetRecID(tx *Tx, rec DBRecord, table, id, attr string, val ...interface{}) (int64, error) {
 	rid, err := GetID(tx, table, id, attr, val...)
 	if err != nil {
 		if utils.VERBOSE > 1 {
@@ -655,7 +809,7 @@ func GetRecID(tx *sql.Tx, rec DBRecord, table, id, attr string, val ...interface
 }
 
 // IfExistMulti checks if given rid exists in given table for provided value conditions
-func IfExistMulti(tx *sql.Tx, table, rid string, args []string, vals ...interface{}) bool {
+func IfExistMulti(tx *Tx, table, rid string, args []string, vals ...interface{}) bool {
 	var stm string
 	var wheres []string
 	if DBOWNER == "sqlite" {
@@ -670,6 +824,7 @@ func IfExistMulti(tx *sql.Tx, table, rid string, args []string, vals ...interfac
 		}
 	}
 	stm = fmt.Sprintf("%s WHERE %s", stm, strings.Join(wheres, " AND "))
+	stm = Rebind(stm)
 	if utils.VERBOSE > 1 {
 		utils.PrintSQL(stm, vals, "execute")
 	}
@@ -685,7 +840,7 @@ func IfExistMulti(tx *sql.Tx, table, rid string, args []string, vals ...interfac
 }
 
 // IfExist check if given rid, attr exists in given table for provided value conditions
-func IfExist(tx *sql.Tx, table, rid, attr string, val ...interface{}) bool {
+func IfExist(tx *Tx, table, rid, attr string, val ...interface{}) bool {
 	// check if our data already exist in DB
 	fid, err := GetID(tx, table, rid, attr, val...)
 	if err == nil {
@@ -906,8 +1061,62 @@ func AddParam(
 	return conds, args
 }
 
+// AddRangeParam adds min/max conditions of given parameter to SQL statement,
+// e.g. min_file_size and max_file_size parameters for file_size one
+func AddRangeParam(
+	name, sqlName string,
+	params Record,
+	conds []string,
+	args []interface{}) ([]string, []interface{}) {
+
+	for _, op := range []string{"min", "max"} {
+		key := fmt.Sprintf("%s_%s", op, name)
+		vals := getValues(params, key)
+		if len(vals) != 1 || vals[0] == "" {
+			continue
+		}
+		cond := fmt.Sprintf(" %s >= %s", sqlName, placeholder(key))
+		if op == "max" {
+			cond = fmt.Sprintf(" %s <= %s", sqlName, placeholder(key))
+		}
+		conds = append(conds, cond)
+		args = append(args, vals[0])
+	}
+	return conds, args
+}
+
+// AddMixParam adds condition of parameter which value is either integer or
+// range of integers, e.g. file_size=1024 or file_size=1024-2048
+func AddMixParam(
+	name, sqlName string,
+	params Record,
+	conds []string,
+	args []interface{}) ([]string, []interface{}) {
+
+	vals := getValues(params, name)
+	if len(vals) != 1 || vals[0] == "" {
+		return conds, args
+	}
+	if minVal, maxVal, err := parseRange(vals[0]); err == nil {
+		cond := fmt.Sprintf(" %s BETWEEN %s AND %s",
+			sqlName, placeholder("min_"+name), placeholder("max_"+name))
+		conds = append(conds, cond)
+		args = append(args, minVal, maxVal)
+		return conds, args
+	}
+	cond := fmt.Sprintf(" %s = %s", sqlName, placeholder(name))
+	conds = append(conds, cond)
+	args = append(args, vals[0])
+	return conds, args
+}
+
+// helper function to convert empty string into SQL NULL value
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
 // IncrementSequences API provide a way to get N unique IDs for given sequence name
-func IncrementSequences(tx *sql.Tx, seq string, n int) ([]int64, error) {
+func IncrementSequences(tx *Tx, seq string, n int) ([]int64, error) {
 	var out []int64
 	if DBOWNER == "sqlite" {
 		ts := time.Now().UnixNano()
@@ -919,6 +1128,9 @@ func IncrementSequences(tx *sql.Tx, seq string, n int) ([]int64, error) {
 	var pid float64
 	for i := 0; i < n; i++ {
 		stm := fmt.Sprintf("select %s.%s.nextval as val from dual", DBOWNER, seq)
+		if utils.POSTGRES {
+			stm = fmt.Sprintf("select nextval('%s.%s') as val", DBOWNER, seq)
+		}
 		err := tx.QueryRow(stm).Scan(&pid)
 		if err != nil {
 			msg := fmt.Sprintf("fail to increment sequence, query='%s'", stm)
@@ -931,7 +1143,7 @@ func IncrementSequences(tx *sql.Tx, seq string, n int) ([]int64, error) {
 }
 
 // IncrementSequence API returns single unique ID for a given sequence
-func IncrementSequence(tx *sql.Tx, seq string) (int64, error) {
+func IncrementSequence(tx *Tx, seq string) (int64, error) {
 	ids, err := IncrementSequences(tx, seq, 1)
 	if len(ids) == 1 && err == nil {
 		return ids[0], nil
@@ -939,25 +1151,6 @@ func IncrementSequence(tx *sql.Tx, seq string) (int64, error) {
 	return 0, Error(err, LastInsertErrorCode, "", "dbs.IncrementSequence")
 }
 
-// LastInsertID returns last insert id of given table and idname parameter
-func LastInsertID(tx *sql.Tx, table, idName string) (int64, error) {
-	stm := fmt.Sprintf("select MAX(%s) from %s.%s", idName, DBOWNER, table)
-	if DBOWNER == "sqlite" {
-		stm = fmt.Sprintf("select MAX(%s) from %s", idName, table)
-	}
-	var pid sql.NullFloat64
-	if utils.VERBOSE > 1 {
-		log.Println("execute", stm)
-	}
-	err := tx.QueryRow(stm).Scan(&pid)
-	if err != nil {
-		msg := fmt.Sprintf("fail to process query='%s'", stm)
-		log.Println(msg)
-		return 0, Error(err, QueryErrorCode, "", "dbs.LastInsertID")
-	}
-	return int64(pid.Float64), nil
-}
-
 // RunsConditions function to handle runs conditions
 func RunsConditions(runs []string, table string) (string, []string, []interface{}, error) {
 	var args []interface{}
@@ -1035,20 +1228,3 @@ func getInt64(record Record, attr string) int64 {
 	log.Printf("WARNING: fail to extract %s from DBS record %+v", attr, record)
 	return 0
 }
-
-// helper function to get next available SiteID
-func getNextId(tx *sql.Tx, table, tableId string) (int64, error) {
-	var err error
-	var tid int64
-	if DBOWNER == "sqlite" {
-		tid, err = LastInsertID(tx, table, tableId)
-		tid += 1
-	} else {
-		tid, err = IncrementSequence(tx, "SEQ_FL")
-	}
-	if err != nil {
-		msg := fmt.Sprintf("dbs.getId(tx, %s, %s)", table, tableId)
-		return tid, Error(err, LastInsertErrorCode, "", msg)
-	}
-	return tid, nil
-}
@@ -0,0 +1,151 @@
+package dbs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/OreCast/DataBookkeeping/utils"
+	validator "github.com/go-playground/validator/v10"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// helper function to initialize DBS test database in temporary area, the
+// database is bootstrapped in the same way as done by server
+func initTestDB(t *testing.T) {
+	t.Helper()
+	utils.STATICDIR = ""
+	RecordValidator = validator.New()
+	dbfile := filepath.Join(t.TempDir(), "dbs-test.db")
+	db, err := sql.Open("sqlite3", fmt.Sprintf("%s?_busy_timeout=30000&_foreign_keys=1", dbfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	DB = db
+	DBTYPE = "sqlite3"
+	DBOWNER = "sqlite"
+	DBSQL = LoadSQL("sqlite")
+	if err := InitDB(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// helper function to create DBS API with given parameters and payload,
+// the API output is written into returned recorder
+func testApi(params Record, payload string) (*API, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	if params == nil {
+		params = make(Record)
+	}
+	api := &API{
+		Writer:      w,
+		Context:     context.Background(),
+		Params:      params,
+		Separator:   ",",
+		CreateBy:    "test",
+		ContentType: "application/json",
+	}
+	if payload != "" {
+		api.Reader = strings.NewReader(payload)
+	}
+	return api, w
+}
+
+// helper function to inject dataset record via InsertDataset API
+func insertTestDataset(t *testing.T, payload string) {
+	t.Helper()
+	api, _ := testApi(nil, payload)
+	if err := api.InsertDataset(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// helper function to decode list of records written by DBS API
+func decodeRecords(t *testing.T, w *httptest.ResponseRecorder) []Record {
+	t.Helper()
+	var records []Record
+	if err := json.Unmarshal(w.Body.Bytes(), &records); err != nil {
+		t.Fatalf("unable to decode API output '%s': %v", w.Body.String(), err)
+	}
+	return records
+}
+
+// helper function to get API output records for given parameters
+func getRecords(t *testing.T, get func(*API) error, params Record) []Record {
+	t.Helper()
+	api, w := testApi(params, "")
+	if err := get(api); err != nil {
+		t.Fatal(err)
+	}
+	return decodeRecords(t, w)
+}
+
+// helper function to get sorted values of given attribute of records
+func recordValues(records []Record, attr string) []string {
+	var out []string
+	for _, rec := range records {
+		out = append(out, fmt.Sprintf("%v", rec[attr]))
+	}
+	sort.Strings(out)
+	return out
+}
+
+// helper function to check that error, or any DBS error nested in it,
+// carries given DBS error code
+func checkErrorCode(t *testing.T, err error, code int) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("expected DBS error code %d, got no error", code)
+	}
+	if !strings.Contains(err.Error(), fmt.Sprintf("DBSError Code:%d ", code)) {
+		t.Errorf("expected DBS error code %d, got %v", code, err)
+	}
+}
+
+// TestClose tests that SQLite write-ahead log is checkpointed into database
+// file when database is closed
+func TestClose(t *testing.T) {
+	initTestDB(t)
+	dbfile := filepath.Join(t.TempDir(), "dbs-wal.db")
+	db, err := sql.Open("sqlite3", fmt.Sprintf("%s?_journal_mode=WAL&_foreign_keys=1", dbfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	DB = db
+	if err := Migrate(-1); err != nil {
+		t.Fatal(err)
+	}
+	insertTestDataset(t, testDataset)
+	if fi, err := os.Stat(dbfile + "-wal"); err != nil || fi.Size() == 0 {
+		t.Fatalf("database changes are not kept in write-ahead log, error %v", err)
+	}
+	if err := Close(); err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := os.Stat(dbfile + "-wal"); err == nil && fi.Size() != 0 {
+		t.Errorf("write-ahead log of %d bytes is not checkpointed", fi.Size())
+	}
+
+	// closed database keeps its data and closing it again is not an error
+	db, err = sql.Open("sqlite3", dbfile+"?mode=ro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	DB = db
+	if records := getRecords(t, (*API).GetDataset, Record{"dataset": "/a/b/c"}); len(records) != 1 {
+		t.Errorf("dataset is lost after close: %v", records)
+	}
+	DB = nil
+	if err := Close(); err != nil {
+		t.Errorf("close of uninitialized database should not fail: %v", err)
+	}
+}
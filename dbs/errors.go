@@ -3,6 +3,7 @@ package dbs
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"runtime"
 	"strings"
 )
@@ -77,6 +78,10 @@ const (
 	PhysicsGroupDoesNotExist                    // 138 PhysicsGroup does not exist in DBS
 	DatasetAccessTypeDoesNotExist               // 139 DatasetAccessType does not exist in DBS
 	DatasetDoesNotExist                         // 140 Dataset does not exist in DBS
+	DatasetHasChildren                          // 141 Dataset is a parent of other datasets in DBS
+	FileDoesNotExist                            // 142 File does not exist in DBS
+	RecordInUseErrorCode                        // 143 record is used by other DBS records
+	TimeoutErrorCode                            // 144 DBS API timeout error
 	LastAvailableErrorCode                      // last available DBS error code
 )
 
@@ -87,6 +92,15 @@ type DBSError struct {
 	Function   string `json:"function"`   // DBS function
 	Code       int    `json:"code"`       // DBS error code
 	Stacktrace string `json:"stacktrace"` // Go stack trace
+	Err        error  `json:"-"`          // wrapped error
+
+	// field-level failures of DBS record validation
+	ValidationErrors ValidationErrors `json:"validation_errors,omitempty"`
+}
+
+// Unwrap returns error wrapped by DBS error
+func (e *DBSError) Unwrap() error {
+	return e.Err
 }
 
 // Error function implements details of DBS error message
@@ -169,8 +183,18 @@ func (e *DBSError) Explain() string {
 		return "Unable to remove record from DB"
 	case InvalidRequestErrorCode:
 		return "Invalid HTTP request"
-	default:
-		return "Not defined"
+	case DatasetParentDoesNotExist:
+		return "Dataset parent does not exist in DBS"
+	case DatasetDoesNotExist:
+		return "Dataset does not exist in DBS"
+	case DatasetHasChildren:
+		return "Dataset is a parent of other datasets in DBS"
+	case FileDoesNotExist:
+		return "File does not exist in DBS"
+	case RecordInUseErrorCode:
+		return "DBS record is used by other records and can't be removed"
+	case TimeoutErrorCode:
+		return "DBS API did not complete within its timeout"
 	}
 	return "Not defined"
 }
@@ -183,11 +207,135 @@ func Error(err error, code int, msg, function string) error {
 	}
 	stackSlice := make([]byte, 1024)
 	s := runtime.Stack(stackSlice, false)
-	return &DBSError{
+	dbsError := &DBSError{
 		Reason:     reason,
 		Message:    msg,
 		Code:       code,
 		Function:   function,
 		Stacktrace: fmt.Sprintf("\n%s", stackSlice[0:s]),
+		Err:        err,
+	}
+	// keep validation failures of wrapped errors
+	var verrs ValidationErrors
+	if errors.As(err, &verrs) {
+		dbsError.ValidationErrors = verrs
+	}
+	return dbsError
+}
+
+// list of DB errors caused by violation of unique or foreign key constraints
+var constraintErrors = []string{
+	"UNIQUE constraint failed",      // SQLite
+	"FOREIGN KEY constraint failed", // SQLite
+	"duplicate key value",           // PostgreSQL
+	"violates foreign key",          // PostgreSQL
+	"ORA-00001",                     // ORACLE unique constraint
+	"ORA-02291",                     // ORACLE parent key not found
+	"ORA-02292",                     // ORACLE child record found
+}
+
+// helper function to check if reason of the error is constraint violation
+func isConstraintError(reason string) bool {
+	for _, msg := range constraintErrors {
+		if strings.Contains(reason, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// list of DB errors caused by unreachable database or connection timeouts
+var unavailableErrors = []string{
+	"connection refused",
+	"connection reset",
+	"bad connection",
+	"database is closed",
+	"no such host",
+	"i/o timeout",
+	"context deadline exceeded",
+	"the database system is starting up", // PostgreSQL
+	"too many clients",                   // PostgreSQL
+	"ORA-12541",                          // ORACLE no listener
+	"ORA-12170",                          // ORACLE connect timeout
+}
+
+// helper function to check if reason of the error is database unavailability
+func isUnavailableError(reason string) bool {
+	for _, msg := range unavailableErrors {
+		if strings.Contains(reason, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// HttpStatus returns HTTP status code of DBS error code
+func (e *DBSError) HttpStatus() int {
+	switch e.Code {
+	case ParseErrorCode, PatternErrorCode, DecodeErrorCode, ParametersErrorCode,
+		UnmarshalErrorCode, ReaderErrorCode, InvalidRequestErrorCode:
+		return http.StatusBadRequest
+	case ContentTypeErrorCode:
+		return http.StatusUnsupportedMediaType
+	case GetIDErrorCode, DatasetDoesNotExist, FileDoesNotExist:
+		return http.StatusNotFound
+	case BlockAlreadyExists, DatasetHasChildren, RecordInUseErrorCode:
+		return http.StatusConflict
+	case ValidateErrorCode, FileDataTypesDoesNotExist, FileParentDoesNotExist,
+		DatasetParentDoesNotExist, ProcessedDatasetDoesNotExist,
+		PrimaryDatasetTypeDoesNotExist, PrimaryDatasetDoesNotExist,
+		ProcessingEraDoesNotExist, AcquisitionEraDoesNotExist,
+		DataTierDoesNotExist, PhysicsGroupDoesNotExist,
+		DatasetAccessTypeDoesNotExist:
+		return http.StatusUnprocessableEntity
+	case InsertErrorCode, UpdateErrorCode, RemoveErrorCode, CommitErrorCode:
+		// records which violate DB constraints conflict with existing ones
+		if isConstraintError(e.Reason) {
+			return http.StatusConflict
+		}
+		return http.StatusInternalServerError
+	case NotImplementedApiCode:
+		return http.StatusNotImplemented
+	case DatabaseErrorCode, TransactionErrorCode:
+		// only connection failures and timeouts make database unavailable
+		if isUnavailableError(e.Reason) {
+			return http.StatusServiceUnavailable
+		}
+		return http.StatusInternalServerError
+	case TimeoutErrorCode:
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
+
+// HttpStatus returns HTTP status code of given error
+func HttpStatus(err error) int {
+	status, _ := ErrorStatus(err)
+	return status
+}
+
+// ErrorStatus returns HTTP status code of given error along with DBS error
+// which defines it. The DBS errors wrap each other and generic errors of outer
+// functions, e.g. commit error, wrap specific errors, e.g. dataset does not
+// exist. Therefore, the first status in chain of DBS errors which differs
+// from internal server error is used.
+func ErrorStatus(err error) (int, *DBSError) {
+	if err == nil {
+		return http.StatusOK, nil
+	}
+	if errors.Is(err, NotImplementedApiErr) {
+		return http.StatusNotImplemented, nil
+	}
+	var outer *DBSError
+	var dbsError *DBSError
+	for errors.As(err, &dbsError) {
+		if outer == nil {
+			outer = dbsError
+		}
+		if code := dbsError.HttpStatus(); code != http.StatusInternalServerError {
+			return code, dbsError
+		}
+		err = dbsError.Err
 	}
+	return http.StatusInternalServerError, outer
 }
@@ -0,0 +1,56 @@
+package dbs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// TestHttpStatus tests mapping of DBS errors into HTTP status codes
+func TestHttpStatus(t *testing.T) {
+	dbErr := errors.New("some database error")
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{nil, http.StatusOK},
+		{errors.New("generic error"), http.StatusInternalServerError},
+		{Error(InvalidParamErr, ParametersErrorCode, "", "test"), http.StatusBadRequest},
+		{Error(InvalidParamErr, ContentTypeErrorCode, "", "test"), http.StatusUnsupportedMediaType},
+		{Error(dbErr, DatasetDoesNotExist, "", "test"), http.StatusNotFound},
+		{Error(dbErr, RecordInUseErrorCode, "", "test"), http.StatusConflict},
+		{Error(dbErr, ValidateErrorCode, "", "test"), http.StatusUnprocessableEntity},
+		{Error(errors.New("UNIQUE constraint failed: SITES.SITE"), InsertErrorCode, "", "test"), http.StatusConflict},
+		{Error(dbErr, InsertErrorCode, "", "test"), http.StatusInternalServerError},
+		{Error(dbErr, DatabaseErrorCode, "", "test"), http.StatusInternalServerError},
+		{Error(dbErr, TransactionErrorCode, "", "test"), http.StatusInternalServerError},
+		{Error(errors.New("dial tcp: connection refused"), DatabaseErrorCode, "", "test"), http.StatusServiceUnavailable},
+		{Error(errors.New("sql: database is closed"), TransactionErrorCode, "", "test"), http.StatusServiceUnavailable},
+		{Error(context.DeadlineExceeded, TransactionErrorCode, "", "test"), http.StatusServiceUnavailable},
+		{Error(context.DeadlineExceeded, TimeoutErrorCode, "", "test"), http.StatusGatewayTimeout},
+		{NotImplementedApiErr, http.StatusNotImplemented},
+		// outer generic error takes status of wrapped specific error
+		{Error(Error(dbErr, DatasetDoesNotExist, "", "inner"), CommitErrorCode, "", "outer"), http.StatusNotFound},
+		{Error(Error(dbErr, InsertErrorCode, "", "inner"), UpdateErrorCode, "", "outer"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if status := HttpStatus(tt.err); status != tt.status {
+			t.Errorf("wrong HTTP status %d of error %v, expect %d", status, tt.err, tt.status)
+		}
+	}
+}
+
+// TestErrorStatus tests that DBS error which defines HTTP status is reported
+func TestErrorStatus(t *testing.T) {
+	inner := Error(InvalidParamErr, DatasetDoesNotExist, "dataset /x/y/z does not exist", "inner")
+	status, dbsError := ErrorStatus(Error(inner, UpdateErrorCode, "", "outer"))
+	if status != http.StatusNotFound || dbsError == nil || dbsError.Function != "inner" {
+		t.Errorf("wrong error status %d of %+v", status, dbsError)
+	}
+	// internal server error is reported by outer DBS error
+	status, dbsError = ErrorStatus(Error(Error(InvalidParamErr, InsertErrorCode, "", "inner"), UpdateErrorCode, "", "outer"))
+	if status != http.StatusInternalServerError || dbsError == nil || dbsError.Function != "outer" {
+		t.Errorf("wrong error status %d of %+v", status, dbsError)
+	}
+}
@@ -3,8 +3,10 @@ package dbs
 // nolint: gocyclo
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -20,12 +22,89 @@ type Files struct {
 	IS_FILE_VALID          int64  `json:"is_file_valid" validate:"number"`
 	DATASET_ID             int64  `json:"dataset_id" validate:"number,gt=0"`
 	META_ID                string `json:"meta_id" validate:"required"`
+	FILE_SIZE              *int64 `json:"file_size" validate:"omitempty,gte=0"`
+	ADLER32                string `json:"adler32"`
+	MD5                    string `json:"md5"`
+	SHA256                 string `json:"sha256"`
+	CONTENT_TYPE           string `json:"content_type"`
+	EVENT_COUNT            *int64 `json:"event_count" validate:"omitempty,gte=0"`
 	CREATION_DATE          int64  `json:"creation_date" validate:"required,number,gt=0"`
 	CREATE_BY              string `json:"create_by" validate:"required"`
 	LAST_MODIFICATION_DATE int64  `json:"last_modification_date" validate:"required,number,gt=0"`
 	LAST_MODIFIED_BY       string `json:"last_modified_by" validate:"required"`
 }
 
+// FileRecord represents input file record of DatasetRecord. It can be
+// provided either as logical file name string or as JSON object with
+// file metadata.
+type FileRecord struct {
+	LogicalFileName string `json:"logical_file_name"`
+	FileSize        *int64 `json:"file_size"`
+	Adler32         string `json:"adler32"`
+	MD5             string `json:"md5"`
+	SHA256          string `json:"sha256"`
+	ContentType     string `json:"content_type"`
+	EventCount      *int64 `json:"event_count"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface for FileRecord
+func (f *FileRecord) UnmarshalJSON(data []byte) error {
+	var lfn string
+	if err := json.Unmarshal(data, &lfn); err == nil {
+		*f = FileRecord{LogicalFileName: lfn}
+		return nil
+	}
+	// use type alias to avoid recursive calls of UnmarshalJSON
+	type fileRecord FileRecord
+	var rec fileRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return err
+	}
+	*f = FileRecord(rec)
+	return nil
+}
+
+// Record returns Files record of given dataset for FileRecord
+func (f *FileRecord) Record(datasetId int64, metaId, createBy string) Files {
+	return Files{
+		LOGICAL_FILE_NAME: f.LogicalFileName,
+		IS_FILE_VALID:     1,
+		DATASET_ID:        datasetId,
+		META_ID:           metaId,
+		FILE_SIZE:         f.FileSize,
+		ADLER32:           f.Adler32,
+		MD5:               f.MD5,
+		SHA256:            f.SHA256,
+		CONTENT_TYPE:      f.ContentType,
+		EVENT_COUNT:       f.EventCount,
+		CREATE_BY:         createBy,
+		LAST_MODIFIED_BY:  createBy,
+	}
+}
+
+// fileFields defines output fields of files API along with their SQL columns
+var fileFields = map[string]string{
+	"file_id":                "F.FILE_ID",
+	"logical_file_name":      "F.LOGICAL_FILE_NAME",
+	"is_file_valid":          "F.IS_FILE_VALID",
+	"dataset_id":             "F.DATASET_ID",
+	"dataset":                "D.DATASET",
+	"meta_id":                "F.META_ID",
+	"file_size":              "F.FILE_SIZE",
+	"adler32":                "F.ADLER32",
+	"md5":                    "F.MD5",
+	"sha256":                 "F.SHA256",
+	"content_type":           "F.CONTENT_TYPE",
+	"event_count":            "F.EVENT_COUNT",
+	"creation_date":          "F.CREATION_DATE",
+	"create_by":              "F.CREATE_BY",
+	"last_modification_date": "F.LAST_MODIFICATION_DATE",
+	"last_modified_by":       "F.LAST_MODIFIED_BY",
+}
+
+// fileSortFields defines output fields of files API which can be used for sorting
+var fileSortFields = []string{"file_id", "logical_file_name", "is_file_valid", "dataset", "creation_date", "last_modification_date"}
+
 // Files DBS API
 //
 //gocyclo:ignore
@@ -40,7 +119,7 @@ func (a *API) GetFile() error {
 	}
 	if val, ok := a.Params["logical_file_name"]; ok {
 		if val != "" {
-			conds, args = AddParam("logical_file_name", "F.FILE", a.Params, conds, args)
+			conds, args = AddParam("logical_file_name", "F.LOGICAL_FILE_NAME", a.Params, conds, args)
 		}
 	}
 	if val, ok := a.Params["dataset"]; ok {
@@ -48,6 +127,36 @@ func (a *API) GetFile() error {
 			conds, args = AddParam("dataset", "D.DATASET", a.Params, conds, args)
 		}
 	}
+	if val, ok := a.Params["is_file_valid"]; ok {
+		if val != "" {
+			conds, args = AddParam("is_file_valid", "F.IS_FILE_VALID", a.Params, conds, args)
+		}
+	}
+	for _, key := range []string{"adler32", "md5", "sha256", "content_type"} {
+		if val, ok := a.Params[key]; ok {
+			if val != "" {
+				sqlName := fmt.Sprintf("F.%s", strings.ToUpper(key))
+				conds, args = AddParam(key, sqlName, a.Params, conds, args)
+			}
+		}
+	}
+	conds, args = AddMixParam("file_size", "F.FILE_SIZE", a.Params, conds, args)
+	conds, args = AddMixParam("event_count", "F.EVENT_COUNT", a.Params, conds, args)
+	conds, args = AddRangeParam("file_size", "F.FILE_SIZE", a.Params, conds, args)
+	conds, args = AddRangeParam("event_count", "F.EVENT_COUNT", a.Params, conds, args)
+	fields, err := GetFields(a.Params, fileFields)
+	if err != nil {
+		return err
+	}
+	order, err := GetSort(a.Params, fileFields, fileSortFields)
+	if err != nil {
+		return err
+	}
+	var page *Page
+	page, conds, args, err = AddPage("file_id", "F.FILE_ID", order, a.Params, conds, args)
+	if err != nil {
+		return err
+	}
 	if utils.VERBOSE > 0 {
 		log.Println("### /file params", a.Params, conds, args)
 	}
@@ -59,10 +168,10 @@ func (a *API) GetFile() error {
 		return Error(err, LoadErrorCode, "", "dbs.files.Files")
 	}
 
-	stm = WhereClause(stm, conds)
+	stm = OrderClause(WhereClause(stm, conds), order, page)
 
 	// use generic query API to fetch the results from DB
-	err = executeAll(a.Writer, a.Separator, stm, args...)
+	err = executeAll(a.Context, a.Writer, a.Separator, fields, page, stm, args...)
 	if err != nil {
 		return Error(err, QueryErrorCode, "", "dbs.files.Files")
 	}
@@ -72,17 +181,322 @@ func (a *API) GetFile() error {
 func (a *API) InsertFile() error {
 	// the API provides Reader which will be used by Decode function to load the HTTP payload
 	// and cast it to Files data structure
-	return insertRecord(&Files{}, a.Reader)
+	return insertRecord(a.Context, &Files{}, a.Reader)
+}
+
+// FileUpdateRecord represents input record of UpdateFile API. The files to
+// update are either given by logical_file_names list, or by /file/*name
+// end-point or dataset parameter of HTTP request.
+type FileUpdateRecord struct {
+	Files       []string `json:"logical_file_names"`
+	IsFileValid *int64   `json:"is_file_valid"`
+	Dataset     string   `json:"dataset"`
+	MetaId      string   `json:"meta_id"`
+}
+
+// FileUpdateReport represents output of UpdateFile API. The datasets which
+// do not have any files after files were moved to another dataset are
+// reported in empty_datasets list and marked with INVALID access type.
+type FileUpdateReport struct {
+	Files         []string `json:"logical_file_names"`
+	IsFileValid   *int64   `json:"is_file_valid,omitempty"`
+	Dataset       string   `json:"dataset,omitempty"`
+	MetaId        string   `json:"meta_id,omitempty"`
+	EmptyDatasets []string `json:"empty_datasets,omitempty"`
 }
+
+// UpdateFile updates validity, dataset and meta_id of given files
 func (a *API) UpdateFile() error {
+	data, err := io.ReadAll(a.Reader)
+	if err != nil {
+		log.Println("fail to read data", err)
+		return Error(err, ReaderErrorCode, "", "dbs.files.UpdateFile")
+	}
+	rec := FileUpdateRecord{}
+	err = json.Unmarshal(data, &rec)
+	if err != nil {
+		log.Println("fail to decode data", err)
+		return Error(err, UnmarshalErrorCode, "", "dbs.files.UpdateFile")
+	}
+	if rec.IsFileValid != nil && *rec.IsFileValid != 0 && *rec.IsFileValid != 1 {
+		msg := fmt.Sprintf("invalid is_file_valid value %d, should be 0 or 1", *rec.IsFileValid)
+		return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.files.UpdateFile")
+	}
+	if lfn, err := getSingleValue(a.Params, "logical_file_name"); err == nil {
+		rec.Files = append(rec.Files, lfn)
+	}
+	dataset, _ := getSingleValue(a.Params, "dataset")
+	if len(rec.Files) == 0 && dataset == "" {
+		msg := "neither logical_file_name nor dataset is provided"
+		return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.files.UpdateFile")
+	}
+	if utils.VERBOSE > 0 {
+		log.Printf("### update files %+v of dataset '%s'", rec, dataset)
+	}
+	report, err := updateFiles(a.Context, &rec, dataset, a.CreateBy)
+	if err != nil {
+		return Error(err, UpdateErrorCode, "", "dbs.files.UpdateFile")
+	}
+	data, err = json.Marshal(report)
+	if err != nil {
+		return Error(err, MarshalErrorCode, "", "dbs.files.UpdateFile")
+	}
+	a.Writer.Write(data)
 	return nil
 }
+
+// helper function to update files within single transaction
+func updateFiles(ctx context.Context, rec *FileUpdateRecord, dataset, modifiedBy string) (FileUpdateReport, error) {
+	report := FileUpdateReport{
+		Files:       []string{},
+		IsFileValid: rec.IsFileValid,
+		Dataset:     rec.Dataset,
+		MetaId:      rec.MetaId,
+	}
+	// start transaction
+	tx, err := BeginTx(ctx)
+	if err != nil {
+		return report, Error(err, TransactionErrorCode, "", "dbs.files.updateFiles")
+	}
+	defer tx.Rollback()
+
+	// collect all files of given dataset
+	lfns := rec.Files
+	if dataset != "" {
+		drec, err := getDataset(tx, dataset)
+		if err != nil {
+			return report, err
+		}
+		files, err := queryStrings(tx, getSQL("select_dataset_files"), drec.DATASET_ID)
+		if err != nil {
+			return report, err
+		}
+		lfns = append(lfns, files...)
+	}
+
+	// find out dataset id files should be moved to
+	var datasetId int64
+	if rec.Dataset != "" {
+		drec, err := getDataset(tx, rec.Dataset)
+		if err != nil {
+			return report, err
+		}
+		datasetId = drec.DATASET_ID
+	}
+
+	var sourceIds []int64
+	for _, lfn := range utils.Set(lfns) {
+		file, err := getFile(tx, lfn)
+		if err != nil {
+			return report, err
+		}
+		if rec.IsFileValid != nil {
+			file.IS_FILE_VALID = *rec.IsFileValid
+		}
+		if datasetId != 0 && datasetId != file.DATASET_ID {
+			if !utils.InList(file.DATASET_ID, sourceIds) {
+				sourceIds = append(sourceIds, file.DATASET_ID)
+			}
+			file.DATASET_ID = datasetId
+		}
+		if rec.MetaId != "" {
+			file.META_ID = rec.MetaId
+		}
+		file.LAST_MODIFICATION_DATE = Date()
+		file.LAST_MODIFIED_BY = modifiedBy
+		if err = file.Update(tx); err != nil {
+			return report, err
+		}
+		report.Files = append(report.Files, lfn)
+	}
+
+	// check if we moved last files of the datasets
+	report.EmptyDatasets, err = invalidateEmptyDatasets(tx, sourceIds, modifiedBy)
+	if err != nil {
+		return report, err
+	}
+
+	// commit all transactions
+	err = tx.Commit()
+	if err != nil {
+		return report, Error(err, CommitErrorCode, "", "dbs.files.updateFiles")
+	}
+	return report, nil
+}
+
+// helper function to fetch file record for given logical file name
+func getFile(tx *Tx, lfn string) (Files, error) {
+	var rec Files
+	var metaId, createBy, modifiedBy sql.NullString
+	var isValid, datasetId, cdate, mdate sql.NullInt64
+	stm := getSQL("select_file_record")
+	err := tx.QueryRow(stm, lfn).Scan(
+		&rec.FILE_ID,
+		&rec.LOGICAL_FILE_NAME,
+		&isValid,
+		&datasetId,
+		&metaId,
+		&cdate,
+		&createBy,
+		&mdate,
+		&modifiedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			msg := fmt.Sprintf("file %s does not exist", lfn)
+			return rec, Error(err, FileDoesNotExist, msg, "dbs.files.getFile")
+		}
+		return rec, Error(err, QueryErrorCode, "", "dbs.files.getFile")
+	}
+	rec.IS_FILE_VALID = isValid.Int64
+	rec.DATASET_ID = datasetId.Int64
+	rec.META_ID = metaId.String
+	rec.CREATION_DATE = cdate.Int64
+	rec.CREATE_BY = createBy.String
+	rec.LAST_MODIFICATION_DATE = mdate.Int64
+	rec.LAST_MODIFIED_BY = modifiedBy.String
+	return rec, nil
+}
+
+// FileDeleteRecord represents input record of DeleteFile API
+type FileDeleteRecord struct {
+	Files []string `json:"logical_file_names"`
+}
+
+// FileDeleteReport represents output of DeleteFile API. It contains list of
+// removed files, files which were not found in DB and datasets which
+// do not have any files after files removal, such datasets are marked
+// with INVALID access type.
+type FileDeleteReport struct {
+	Files         []string `json:"logical_file_names"`
+	NotFound      []string `json:"not_found"`
+	EmptyDatasets []string `json:"empty_datasets"`
+}
+
+// DeleteFile deletes files given either by /file/*name end-point or
+// by logical_file_names list of HTTP payload
 func (a *API) DeleteFile() error {
+	rec := FileDeleteRecord{}
+	if a.Reader != nil {
+		data, err := io.ReadAll(a.Reader)
+		if err != nil {
+			log.Println("fail to read data", err)
+			return Error(err, ReaderErrorCode, "", "dbs.files.DeleteFile")
+		}
+		if len(data) > 0 {
+			err = json.Unmarshal(data, &rec)
+			if err != nil {
+				log.Println("fail to decode data", err)
+				return Error(err, UnmarshalErrorCode, "", "dbs.files.DeleteFile")
+			}
+		}
+	}
+	if lfn, err := getSingleValue(a.Params, "logical_file_name"); err == nil {
+		rec.Files = append(rec.Files, lfn)
+	}
+	if len(rec.Files) == 0 {
+		msg := "no logical_file_name is provided"
+		return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.files.DeleteFile")
+	}
+	report, err := deleteFiles(a.Context, rec.Files, a.CreateBy)
+	if err != nil {
+		return Error(err, RemoveErrorCode, "", "dbs.files.DeleteFile")
+	}
+	for _, d := range report.EmptyDatasets {
+		log.Printf("WARNING: dataset %s does not have any files", d)
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		return Error(err, MarshalErrorCode, "", "dbs.files.DeleteFile")
+	}
+	a.Writer.Write(data)
 	return nil
 }
 
+// helper function to delete files within single transaction, datasets left
+// without files are invalidated in the same transaction
+func deleteFiles(ctx context.Context, lfns []string, modifiedBy string) (FileDeleteReport, error) {
+	report := FileDeleteReport{
+		Files:         []string{},
+		NotFound:      []string{},
+		EmptyDatasets: []string{},
+	}
+	// start transaction
+	tx, err := BeginTx(ctx)
+	if err != nil {
+		return report, Error(err, TransactionErrorCode, "", "dbs.files.deleteFiles")
+	}
+	defer tx.Rollback()
+
+	var datasetIds []int64
+	for _, lfn := range utils.Set(lfns) {
+		file, err := getFile(tx, lfn)
+		if err != nil {
+			var dbsError *DBSError
+			if errors.As(err, &dbsError) && dbsError.Code == FileDoesNotExist {
+				report.NotFound = append(report.NotFound, lfn)
+				continue
+			}
+			return report, err
+		}
+		stm := getSQL("delete_file")
+		if utils.VERBOSE > 0 {
+			log.Printf("Delete Files file_id=%d lfn=%s", file.FILE_ID, file.LOGICAL_FILE_NAME)
+		}
+		if _, err = tx.Exec(stm, file.FILE_ID); err != nil {
+			return report, Error(err, RemoveErrorCode, "", "dbs.files.deleteFiles")
+		}
+		report.Files = append(report.Files, lfn)
+		if !utils.InList(file.DATASET_ID, datasetIds) {
+			datasetIds = append(datasetIds, file.DATASET_ID)
+		}
+	}
+
+	// check if we removed last files of the datasets
+	report.EmptyDatasets, err = invalidateEmptyDatasets(tx, datasetIds, modifiedBy)
+	if err != nil {
+		return report, err
+	}
+
+	// commit all transactions
+	err = tx.Commit()
+	if err != nil {
+		return report, Error(err, CommitErrorCode, "", "dbs.files.deleteFiles")
+	}
+	return report, nil
+}
+
+// helper function to mark datasets left without files with INVALID access
+// type, it returns names of such datasets
+func invalidateEmptyDatasets(tx *Tx, datasetIds []int64, modifiedBy string) ([]string, error) {
+	datasets := []string{}
+	for _, did := range datasetIds {
+		var nfiles int64
+		err := tx.QueryRow(getSQL("count_dataset_files"), did).Scan(&nfiles)
+		if err != nil {
+			return datasets, Error(err, QueryErrorCode, "", "dbs.files.invalidateEmptyDatasets")
+		}
+		if nfiles > 0 {
+			continue
+		}
+		names, err := queryStrings(tx, getSQL("select_dataset_name"), did)
+		if err != nil {
+			return datasets, err
+		}
+		stm := getSQL("update_dataset_access_type")
+		if utils.VERBOSE > 0 {
+			log.Printf("Invalidate Datasets dataset_id=%d", did)
+		}
+		_, err = tx.Exec(stm, "INVALID", Date(), modifiedBy, did)
+		if err != nil {
+			return datasets, Error(err, UpdateErrorCode, "", "dbs.files.invalidateEmptyDatasets")
+		}
+		datasets = append(datasets, names...)
+	}
+	return datasets, nil
+}
+
 // Insert implementation of Files
-func (r *Files) Insert(tx *sql.Tx) error {
+func (r *Files) Insert(tx *Tx) error {
 	var err error
 	if r.FILE_ID == 0 {
 		fileID, err := getNextId(tx, "FILES", "FILE_ID")
@@ -101,18 +515,24 @@ func (r *Files) Insert(tx *sql.Tx) error {
 	}
 	// get SQL statement from static area
 	stm := getSQL("insert_file")
-	if utils.VERBOSE > 0 {
-		log.Printf("Insert Files file_id=%d lfn=%s", r.FILE_ID, r.LOGICAL_FILE_NAME)
-	} else if utils.VERBOSE > 1 {
+	if utils.VERBOSE > 1 {
 		log.Printf("Insert Files\n%s\n%+v", stm, r)
+	} else if utils.VERBOSE > 0 {
+		log.Printf("Insert Files file_id=%d lfn=%s", r.FILE_ID, r.LOGICAL_FILE_NAME)
 	}
-	_, err = tx.Exec(
+	res, err := tx.Exec(
 		stm,
-		r.FILE_ID,
+		nullId(r.FILE_ID),
 		r.LOGICAL_FILE_NAME,
 		r.IS_FILE_VALID,
 		r.DATASET_ID,
 		r.META_ID,
+		r.FILE_SIZE,
+		nullString(r.ADLER32),
+		nullString(r.MD5),
+		nullString(r.SHA256),
+		nullString(r.CONTENT_TYPE),
+		r.EVENT_COUNT,
 		r.CREATION_DATE,
 		r.CREATE_BY,
 		r.LAST_MODIFICATION_DATE,
@@ -123,26 +543,59 @@ func (r *Files) Insert(tx *sql.Tx) error {
 		}
 		return Error(err, InsertErrorCode, "", "dbs.files.Insert")
 	}
+	// obtain id of the record if it was assigned by DB
+	r.FILE_ID, err = insertedId(res, r.FILE_ID)
+	return err
+}
+
+// Update implementation of Files
+func (r *Files) Update(tx *Tx) error {
+	err := r.Validate()
+	if err != nil {
+		log.Println("unable to validate record", err)
+		return Error(err, ValidateErrorCode, "", "dbs.files.Update")
+	}
+	// get SQL statement from static area
+	stm := getSQL("update_file")
+	if utils.VERBOSE > 1 {
+		log.Printf("Update Files\n%s\n%+v", stm, r)
+	} else if utils.VERBOSE > 0 {
+		log.Printf("Update Files file_id=%d lfn=%s", r.FILE_ID, r.LOGICAL_FILE_NAME)
+	}
+	_, err = tx.Exec(
+		stm,
+		r.IS_FILE_VALID,
+		r.DATASET_ID,
+		r.META_ID,
+		r.LAST_MODIFICATION_DATE,
+		r.LAST_MODIFIED_BY,
+		r.FILE_ID)
+	if err != nil {
+		if utils.VERBOSE > 0 {
+			log.Println("unable to update files, error", err)
+		}
+		return Error(err, UpdateErrorCode, "", "dbs.files.Update")
+	}
 	return nil
 }
 
 // Validate implementation of Files
 func (r *Files) Validate() error {
-	if err := RecordValidator.Struct(*r); err != nil {
-		return DecodeValidatorError(r, err)
+	var errs ValidationErrors
+	errs.AddStruct(*r)
+	errs.AddPattern("logical_file_name", "logical_file_name", r.LOGICAL_FILE_NAME)
+	if r.ADLER32 != "" && !adler32Pattern.MatchString(r.ADLER32) {
+		errs.Add("adler32", r.ADLER32, "adler32 checksum")
 	}
-	if err := CheckPattern("logical_file_name", r.LOGICAL_FILE_NAME); err != nil {
-		return Error(err, PatternErrorCode, "", "dbs.files.Validate")
+	if r.MD5 != "" && !md5Pattern.MatchString(r.MD5) {
+		errs.Add("md5", r.MD5, "md5 checksum")
 	}
-	if matched := unixTimePattern.MatchString(fmt.Sprintf("%d", r.CREATION_DATE)); !matched {
-		msg := "invalid pattern for creation date"
-		return Error(InvalidParamErr, PatternErrorCode, msg, "dbs.files.Validate")
+	if r.SHA256 != "" && !sha256Pattern.MatchString(r.SHA256) {
+		errs.Add("sha256", r.SHA256, "sha256 checksum")
 	}
-	if matched := unixTimePattern.MatchString(fmt.Sprintf("%d", r.LAST_MODIFICATION_DATE)); !matched {
-		msg := "invalid pattern for last modification date"
-		return Error(InvalidParamErr, PatternErrorCode, msg, "dbs.files.Validate")
-	}
-	return nil
+	errs.AddDate("creation_date", r.CREATION_DATE)
+	errs.AddDate("last_modification_date", r.LAST_MODIFICATION_DATE)
+	return validationError(errs, "dbs.files.Validate")
 }
 
 // SetDefaults implements set defaults for Files
@@ -0,0 +1,217 @@
+package dbs
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// helper function to update files via UpdateFile API
+func updateTestFiles(t *testing.T, params Record, payload string) (FileUpdateReport, error) {
+	t.Helper()
+	var report FileUpdateReport
+	api, w := testApi(params, payload)
+	if err := api.UpdateFile(); err != nil {
+		return report, err
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	return report, nil
+}
+
+// TestUpdateFile tests file invalidation, move to another dataset and
+// is_file_valid filter of files API
+func TestUpdateFile(t *testing.T) {
+	initTestDB(t)
+	insertTestDataset(t, testDataset)
+	insertTestDataset(t, `{"dataset": "/x/y/z", "buckets": [], "site": "s1",
+		"processing": "p1", "meta_id": "m1", "files": ["/x/f1"]}`)
+
+	// invalidate single file given by its name
+	report, err := updateTestFiles(t, Record{"logical_file_name": "/a/f1"}, `{"is_file_valid": 0}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(report.Files, []string{"/a/f1"}) {
+		t.Errorf("wrong update report %+v", report)
+	}
+	valid := recordValues(getRecords(t, (*API).GetFile,
+		Record{"dataset": "/a/b/c", "is_file_valid": "1"}), "logical_file_name")
+	if !reflect.DeepEqual(valid, []string{"/a/f2"}) {
+		t.Errorf("wrong valid files %v", valid)
+	}
+
+	// invalidate all files of a dataset
+	report, err = updateTestFiles(t, Record{"dataset": "/a/b/c"}, `{"is_file_valid": 0}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Files) != 2 {
+		t.Errorf("wrong bulk update report %+v", report)
+	}
+	invalid := recordValues(getRecords(t, (*API).GetFile,
+		Record{"dataset": "/a/b/c", "is_file_valid": "0"}), "logical_file_name")
+	if !reflect.DeepEqual(invalid, []string{"/a/f1", "/a/f2"}) {
+		t.Errorf("wrong invalid files %v", invalid)
+	}
+
+	// move file to another dataset along with new meta_id
+	payload := `{"logical_file_names": ["/a/f2"], "dataset": "/x/y/z", "meta_id": "m2"}`
+	if _, err = updateTestFiles(t, nil, payload); err != nil {
+		t.Fatal(err)
+	}
+	records := getRecords(t, (*API).GetFile, Record{"logical_file_name": "/a/f2"})
+	if len(records) != 1 || records[0]["dataset"] != "/x/y/z" || records[0]["meta_id"] != "m2" {
+		t.Errorf("file is not moved: %v", records)
+	}
+
+	// move of last dataset file invalidates source dataset
+	report, err = updateTestFiles(t, nil, `{"logical_file_names": ["/a/f1"], "dataset": "/x/y/z"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(report.EmptyDatasets, []string{"/a/b/c"}) {
+		t.Errorf("wrong empty datasets %+v", report)
+	}
+	records = getRecords(t, (*API).GetDataset, Record{"dataset": "/a/b/c"})
+	if len(records) != 1 || records[0]["dataset_access_type"] != "INVALID" {
+		t.Errorf("empty dataset is not invalidated: %v", records)
+	}
+}
+
+// TestUpdateFileErrors tests rejected file updates
+func TestUpdateFileErrors(t *testing.T) {
+	initTestDB(t)
+	insertTestDataset(t, testDataset)
+
+	_, err := updateTestFiles(t, Record{"logical_file_name": "/a/f1"}, `{"is_file_valid": 2}`)
+	checkErrorCode(t, err, ParametersErrorCode)
+	_, err = updateTestFiles(t, nil, `{"is_file_valid": 0}`)
+	checkErrorCode(t, err, ParametersErrorCode)
+	_, err = updateTestFiles(t, nil, `{"logical_file_names": ["/a/f1", "/a/f9"], "is_file_valid": 0}`)
+	checkErrorCode(t, err, FileDoesNotExist)
+	_, err = updateTestFiles(t, Record{"logical_file_name": "/a/f1"}, `{"dataset": "/x/y/z"}`)
+	checkErrorCode(t, err, DatasetDoesNotExist)
+
+	// failed update should not modify any file
+	invalid := getRecords(t, (*API).GetFile, Record{"is_file_valid": "0"})
+	if len(invalid) != 0 {
+		t.Errorf("files are modified by failed update: %v", invalid)
+	}
+}
+
+// helper function to delete files via DeleteFile API
+func deleteTestFiles(t *testing.T, params Record, payload string) (FileDeleteReport, error) {
+	t.Helper()
+	var report FileDeleteReport
+	api, w := testApi(params, payload)
+	if err := api.DeleteFile(); err != nil {
+		return report, err
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	return report, nil
+}
+
+// TestDeleteFile tests files removal and invalidation of datasets left
+// without files
+func TestDeleteFile(t *testing.T) {
+	initTestDB(t)
+	insertTestDataset(t, testDataset)
+	records := getRecords(t, (*API).GetDataset, Record{"dataset": "/a/b/c"})
+	if len(records) != 1 || records[0]["dataset_access_type"] != "VALID" {
+		t.Fatalf("new dataset should be valid: %v", records)
+	}
+
+	// removal of some dataset files keeps dataset valid
+	report, err := deleteTestFiles(t, Record{"logical_file_name": "/a/f1"}, `{"logical_file_names": ["/a/f9"]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(report.Files, []string{"/a/f1"}) ||
+		!reflect.DeepEqual(report.NotFound, []string{"/a/f9"}) || len(report.EmptyDatasets) != 0 {
+		t.Errorf("wrong delete report %+v", report)
+	}
+	records = getRecords(t, (*API).GetDataset, Record{"dataset": "/a/b/c"})
+	if records[0]["dataset_access_type"] != "VALID" {
+		t.Errorf("dataset with files is invalidated: %v", records)
+	}
+
+	// removal of last dataset file invalidates the dataset
+	report, err = deleteTestFiles(t, nil, `{"logical_file_names": ["/a/f2"]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(report.EmptyDatasets, []string{"/a/b/c"}) {
+		t.Errorf("wrong empty datasets %+v", report)
+	}
+	records = getRecords(t, (*API).GetDataset, Record{"dataset": "/a/b/c"})
+	if records[0]["dataset_access_type"] != "INVALID" || records[0]["last_modified_by"] != "test" {
+		t.Errorf("empty dataset is not invalidated: %v", records)
+	}
+
+	_, err = deleteTestFiles(t, nil, `{"logical_file_names": []}`)
+	checkErrorCode(t, err, ParametersErrorCode)
+}
+
+// TestFileMetadata tests injection of files given either by name or by
+// metadata record along with metadata filters of files API
+func TestFileMetadata(t *testing.T) {
+	initTestDB(t)
+	insertTestDataset(t, `{"dataset": "/a/b/c", "buckets": [], "site": "s1",
+		"processing": "p1", "meta_id": "m1", "files": [
+		"/a/f1",
+		{"logical_file_name": "/a/f2", "file_size": 1024, "adler32": "0a1b2c3d",
+		 "content_type": "image/png", "event_count": 10},
+		{"logical_file_name": "/a/f3", "file_size": 4096, "content_type": "text/plain"}]}`)
+
+	records := getRecords(t, (*API).GetFile, Record{"logical_file_name": "/a/f2"})
+	if len(records) != 1 {
+		t.Fatalf("expected single file, got %v", records)
+	}
+	rec := records[0]
+	if rec["file_size"] != 1024.0 || rec["adler32"] != "0a1b2c3d" ||
+		rec["content_type"] != "image/png" || rec["event_count"] != 10.0 {
+		t.Errorf("wrong file metadata %v", rec)
+	}
+	records = getRecords(t, (*API).GetFile, Record{"logical_file_name": "/a/f1"})
+	if len(records) != 1 || records[0]["file_size"] != nil || records[0]["is_file_valid"] != 1.0 {
+		t.Errorf("wrong metadata of file given by name %v", records)
+	}
+
+	tests := []struct {
+		params Record
+		files  []string
+	}{
+		{Record{"dataset": "/a/b/c", "content_type": "image/*"}, []string{"/a/f2"}},
+		{Record{"dataset": "/a/b/c", "adler32": "0a1b2c3d"}, []string{"/a/f2"}},
+		{Record{"dataset": "/a/b/c", "file_size": "1024"}, []string{"/a/f2"}},
+		{Record{"dataset": "/a/b/c", "file_size": "1000-5000"}, []string{"/a/f2", "/a/f3"}},
+		{Record{"dataset": "/a/b/c", "min_file_size": "2048"}, []string{"/a/f3"}},
+		{Record{"dataset": "/a/b/c", "max_event_count": "10"}, []string{"/a/f2"}},
+	}
+	for _, tt := range tests {
+		files := recordValues(getRecords(t, (*API).GetFile, tt.params), "logical_file_name")
+		if !reflect.DeepEqual(files, tt.files) {
+			t.Errorf("wrong files %v for %v, expect %v", files, tt.params, tt.files)
+		}
+	}
+
+	// files with invalid metadata are rejected
+	file := fmt.Sprintf(`{"logical_file_name": "/a/f4", "dataset_id": %v, "meta_id": "m1",
+		"create_by": "test", "last_modified_by": "test", `, records[0]["dataset_id"])
+	for _, meta := range []string{`"md5": "xyz"`, `"sha256": "0a1b"`, `"adler32": "0a1b2c3d4"`, `"file_size": -1`} {
+		checkErrorCode(t, callRecordAPI((*API).InsertFile, nil, file+meta+"}"), ValidateErrorCode)
+	}
+	err := callRecordAPI((*API).InsertFile, nil, file+`"md5": "0123456789abcdef0123456789abcdef"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records = getRecords(t, (*API).GetFile, Record{"md5": "0123456789abcdef0123456789abcdef"})
+	if len(records) != 1 || records[0]["logical_file_name"] != "/a/f4" {
+		t.Errorf("wrong files with md5 checksum %v", records)
+	}
+}
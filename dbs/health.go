@@ -0,0 +1,106 @@
+package dbs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/OreCast/DataBookkeeping/utils"
+)
+
+// ReadinessTimeout defines maximum time of DB queries used by readiness check
+var ReadinessTimeout = 5 * time.Second
+
+// PoolStats represents connection pool statistics of DBS database
+type PoolStats struct {
+	MaxOpenConnections int   `json:"max_open_connections"`
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`
+	WaitDuration       int64 `json:"wait_duration_ms"`
+	MaxIdleClosed      int64 `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64 `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64 `json:"max_lifetime_closed"`
+}
+
+// Readiness represents readiness status of DBS server and its database
+type Readiness struct {
+	Ready         bool      `json:"ready"`
+	SchemaVersion int       `json:"schema_version"`
+	LatestVersion int       `json:"latest_schema_version"`
+	Errors        []string  `json:"errors,omitempty"`
+	Pool          PoolStats `json:"db_pool"`
+}
+
+// GetPoolStats provides connection pool statistics of DBS database
+func GetPoolStats() PoolStats {
+	var s sql.DBStats
+	if DB != nil {
+		s = DB.Stats()
+	}
+	return PoolStats{
+		MaxOpenConnections: s.MaxOpenConnections,
+		OpenConnections:    s.OpenConnections,
+		InUse:              s.InUse,
+		Idle:               s.Idle,
+		WaitCount:          s.WaitCount,
+		WaitDuration:       s.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      s.MaxIdleClosed,
+		MaxIdleTimeClosed:  s.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  s.MaxLifetimeClosed,
+	}
+}
+
+// CheckReadiness checks that DBS database is reachable, can serve queries
+// and has up to date schema. The server is not ready if any check fails.
+func CheckReadiness() Readiness {
+	status := Readiness{Pool: GetPoolStats()}
+	if DB == nil {
+		status.Errors = append(status.Errors, "database is not initialized")
+		return status
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), ReadinessTimeout)
+	defer cancel()
+	if err := DB.PingContext(ctx); err != nil {
+		status.Errors = append(status.Errors, fmt.Sprintf("database ping error: %v", err))
+		return status
+	}
+	if err := GetTestData(ctx); err != nil {
+		status.Errors = append(status.Errors, fmt.Sprintf("database test query error: %v", err))
+	}
+	if err := checkSchema(ctx, &status); err != nil {
+		status.Errors = append(status.Errors, err.Error())
+	}
+	status.Ready = len(status.Errors) == 0
+	return status
+}
+
+// helper function to check that schema version of the database matches
+// latest migration, in contrast to SchemaVersion it does not create
+// schema_version table
+func checkSchema(ctx context.Context, status *Readiness) error {
+	if !hasMigrations() {
+		// backend without migrations does not have versioned schema
+		return nil
+	}
+	latest, err := LatestSchemaVersion()
+	if err != nil {
+		return err
+	}
+	status.LatestVersion = latest
+	var version sql.NullInt64
+	stm := Rebind(getSQL("select_schema_version"))
+	if utils.VERBOSE > 1 {
+		utils.PrintSQL(stm, nil, "execute")
+	}
+	if err := DB.QueryRowContext(ctx, stm).Scan(&version); err != nil {
+		return fmt.Errorf("unable to read schema version: %v", err)
+	}
+	status.SchemaVersion = int(version.Int64)
+	if status.SchemaVersion != latest {
+		return fmt.Errorf("database schema version %d does not match latest version %d", status.SchemaVersion, latest)
+	}
+	return nil
+}
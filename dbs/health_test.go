@@ -0,0 +1,71 @@
+package dbs
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// helper function to check readiness status along with its error
+func checkReadiness(t *testing.T, ready bool, errMsg string) Readiness {
+	t.Helper()
+	status := CheckReadiness()
+	if status.Ready != ready {
+		t.Errorf("wrong readiness %+v", status)
+	}
+	if errMsg != "" && !strings.Contains(strings.Join(status.Errors, "\n"), errMsg) {
+		t.Errorf("readiness errors %v do not contain '%s'", status.Errors, errMsg)
+	}
+	return status
+}
+
+// TestReadiness tests readiness check of up to date, outdated and newer
+// database schema
+func TestReadiness(t *testing.T) {
+	initTestDB(t)
+	latest, err := LatestSchemaVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := checkReadiness(t, true, "")
+	if status.SchemaVersion != latest || status.LatestVersion != latest || len(status.Errors) != 0 {
+		t.Errorf("wrong readiness status %+v", status)
+	}
+
+	if err := Migrate(latest - 1); err != nil {
+		t.Fatal(err)
+	}
+	checkReadiness(t, false, "does not match latest version")
+	if err := Migrate(-1); err != nil {
+		t.Fatal(err)
+	}
+
+	// database schema ahead of server is not supported either
+	stm := Rebind(getSQL("insert_schema_version"))
+	if _, err := DB.Exec(stm, latest+1, "future", Date()); err != nil {
+		t.Fatal(err)
+	}
+	status = checkReadiness(t, false, "does not match latest version")
+	if status.SchemaVersion != latest+1 {
+		t.Errorf("wrong schema version %+v", status)
+	}
+}
+
+// TestReadinessErrors tests readiness of unreachable database
+func TestReadinessErrors(t *testing.T) {
+	initTestDB(t)
+	timeout := ReadinessTimeout
+	t.Cleanup(func() { ReadinessTimeout = timeout })
+
+	// expired readiness context fails database queries
+	ReadinessTimeout = time.Nanosecond
+	checkReadiness(t, false, "deadline exceeded")
+	ReadinessTimeout = timeout
+
+	DB.Close()
+	checkReadiness(t, false, "database ping error")
+	db := DB
+	DB = nil
+	t.Cleanup(func() { DB = db })
+	checkReadiness(t, false, "database is not initialized")
+}
@@ -0,0 +1,111 @@
+package dbs
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/OreCast/DataBookkeeping/utils"
+)
+
+// IDAllocator provides primary keys of new records of DBS tables. Depending
+// on DB backend the id is either allocated before insert or assigned by DB
+// during insert of the record.
+type IDAllocator interface {
+	// NextID returns id of new record of given table, zero id means that
+	// id will be assigned by DB during insert of the record
+	NextID(tx *Tx, table, idName string) (int64, error)
+	// InsertedID returns id of inserted record from given insert result
+	// and id obtained from NextID call
+	InsertedID(res sql.Result, id int64) (int64, error)
+}
+
+// SQLiteIDs allocates ids via SQLite AUTOINCREMENT primary keys
+type SQLiteIDs struct{}
+
+// NextID implementation of IDAllocator for SQLite
+func (a SQLiteIDs) NextID(tx *Tx, table, idName string) (int64, error) {
+	// ids are assigned by SQLite when NULL is inserted into primary key
+	return 0, nil
+}
+
+// InsertedID implementation of IDAllocator for SQLite
+func (a SQLiteIDs) InsertedID(res sql.Result, id int64) (int64, error) {
+	if id != 0 {
+		return id, nil
+	}
+	return res.LastInsertId()
+}
+
+// PostgresIDs allocates ids from sequences of PostgreSQL identity columns
+type PostgresIDs struct{}
+
+// NextID implementation of IDAllocator for PostgreSQL
+func (a PostgresIDs) NextID(tx *Tx, table, idName string) (int64, error) {
+	stm := fmt.Sprintf(
+		"SELECT nextval(pg_get_serial_sequence('%s.%s', '%s'))",
+		DBOWNER, strings.ToLower(table), strings.ToLower(idName))
+	if utils.VERBOSE > 1 {
+		log.Println("execute", stm)
+	}
+	var tid int64
+	if err := tx.QueryRow(stm).Scan(&tid); err != nil {
+		msg := fmt.Sprintf("fail to process query='%s'", stm)
+		log.Println(msg)
+		return 0, Error(err, QueryErrorCode, "", "dbs.PostgresIDs.NextID")
+	}
+	return tid, nil
+}
+
+// InsertedID implementation of IDAllocator for PostgreSQL
+func (a PostgresIDs) InsertedID(res sql.Result, id int64) (int64, error) {
+	return id, nil
+}
+
+// OracleIDs allocates ids from per-table ORACLE sequences, e.g. SEQ_FILES
+type OracleIDs struct{}
+
+// NextID implementation of IDAllocator for ORACLE
+func (a OracleIDs) NextID(tx *Tx, table, idName string) (int64, error) {
+	return IncrementSequence(tx, fmt.Sprintf("SEQ_%s", strings.ToUpper(table)))
+}
+
+// InsertedID implementation of IDAllocator for ORACLE
+func (a OracleIDs) InsertedID(res sql.Result, id int64) (int64, error) {
+	return id, nil
+}
+
+// IDs returns IDAllocator of underlying DB backend
+func IDs() IDAllocator {
+	if utils.ORACLE {
+		return OracleIDs{}
+	} else if utils.POSTGRES {
+		return PostgresIDs{}
+	}
+	return SQLiteIDs{}
+}
+
+// helper function to get id of new record of given table
+func getNextId(tx *Tx, table, tableId string) (int64, error) {
+	tid, err := IDs().NextID(tx, table, tableId)
+	if err != nil {
+		msg := fmt.Sprintf("dbs.getNextId(tx, %s, %s)", table, tableId)
+		return tid, Error(err, LastInsertErrorCode, "", msg)
+	}
+	return tid, nil
+}
+
+// helper function to get id of inserted record
+func insertedId(res sql.Result, id int64) (int64, error) {
+	tid, err := IDs().InsertedID(res, id)
+	if err != nil {
+		return tid, Error(err, LastInsertErrorCode, "", "dbs.insertedId")
+	}
+	return tid, nil
+}
+
+// helper function to convert zero id into SQL NULL value
+func nullId(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id != 0}
+}
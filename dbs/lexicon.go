@@ -0,0 +1,128 @@
+package dbs
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/OreCast/DataBookkeeping/utils"
+)
+
+// LexiconFile defines location of OreCast lexicon file, if it is not set
+// the lexicon.json from DBS static files is used
+var LexiconFile string
+
+// RequiredLexicons defines lexicon patterns which lexicon file should provide
+var RequiredLexicons = []string{"dataset", "logical_file_name", "site", "bucket", "processing", "meta_id"}
+
+// LexiconStatus represents source and load time of active lexicon
+type LexiconStatus struct {
+	Source   string    `json:"source"`   // lexicon file or static lexicon.json
+	Loaded   time.Time `json:"loaded"`   // load time of the lexicon
+	Patterns []Lexicon `json:"patterns"` // active lexicon patterns
+}
+
+// lexicon state guarded by lexiconMutex since lexicon can be reloaded
+// while server serves requests
+var lexiconMutex sync.RWMutex
+var lexiconSource string
+var lexiconLoaded time.Time
+
+// helper function to get lexicon pattern of given name
+func lexiconPattern(name string) (LexiconPattern, bool) {
+	lexiconMutex.RLock()
+	defer lexiconMutex.RUnlock()
+	p, ok := LexiconPatterns[name]
+	return p, ok
+}
+
+// SetLexiconPatterns replaces active lexicon patterns
+func SetLexiconPatterns(pmap map[string]LexiconPattern, source string) {
+	lexiconMutex.Lock()
+	defer lexiconMutex.Unlock()
+	LexiconPatterns = pmap
+	lexiconSource = source
+	lexiconLoaded = time.Now()
+}
+
+// GetLexiconStatus provides active lexicon patterns sorted by their names
+// along with their source and load time
+func GetLexiconStatus() LexiconStatus {
+	lexiconMutex.RLock()
+	defer lexiconMutex.RUnlock()
+	status := LexiconStatus{Source: lexiconSource, Loaded: lexiconLoaded, Patterns: []Lexicon{}}
+	for _, p := range LexiconPatterns {
+		status.Patterns = append(status.Patterns, p.Lexicon)
+	}
+	sort.Slice(status.Patterns, func(i, j int) bool {
+		return status.Patterns[i].Name < status.Patterns[j].Name
+	})
+	return status
+}
+
+// LoadLexicon loads OreCast lexicon either from LexiconFile or from
+// lexicon.json of DBS static files. The active lexicon is kept if new one
+// can't be loaded or misses any of required patterns.
+func LoadLexicon() error {
+	var pmap map[string]LexiconPattern
+	var err error
+	source := LexiconFile
+	if LexiconFile != "" {
+		pmap, err = LoadPatterns(LexiconFile)
+	} else {
+		source = "static/lexicon.json"
+		var data []byte
+		data, err = fs.ReadFile(utils.StaticFS(), "lexicon.json")
+		if err != nil {
+			return Error(err, ReaderErrorCode, "unable to read static lexicon.json", "dbs.lexicon.LoadLexicon")
+		}
+		pmap, err = parsePatterns(data)
+	}
+	if err != nil {
+		return err
+	}
+	for _, name := range RequiredLexicons {
+		if _, ok := pmap[name]; !ok {
+			msg := fmt.Sprintf("lexicon %s does not provide %s pattern", source, name)
+			return Error(InvalidParamErr, PatternErrorCode, msg, "dbs.lexicon.LoadLexicon")
+		}
+	}
+	SetLexiconPatterns(pmap, source)
+	log.Printf("loaded %d lexicon patterns from %s", len(pmap), source)
+	return nil
+}
+
+// WatchLexicon checks modification time of LexiconFile with given interval
+// and reloads lexicon when the file is changed until context is done
+func WatchLexicon(ctx context.Context, interval time.Duration) {
+	if LexiconFile == "" || interval <= 0 {
+		return
+	}
+	var mtime time.Time
+	if fi, err := os.Stat(LexiconFile); err == nil {
+		mtime = fi.ModTime()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fi, err := os.Stat(LexiconFile)
+			if err != nil || fi.ModTime().Equal(mtime) {
+				continue
+			}
+			mtime = fi.ModTime()
+			log.Printf("lexicon file %s is changed, reload lexicon", LexiconFile)
+			if err := LoadLexicon(); err != nil {
+				log.Println("unable to reload lexicon, keep active one", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package dbs
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// helper function to write lexicon file with patterns of required lexicons,
+// the dataset lexicon is defined by given pattern
+func writeTestLexicon(t *testing.T, fname, datasetPattern string) {
+	t.Helper()
+	var records []Lexicon
+	for _, name := range RequiredLexicons {
+		pattern := "^[a-z0-9]+$"
+		if name == "dataset" {
+			pattern = datasetPattern
+		}
+		records = append(records, Lexicon{Name: name, Patterns: []string{pattern}, Length: 100})
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fname, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestLoadLexicon tests loading of lexicon file
+func TestLoadLexicon(t *testing.T) {
+	loadTestLexicon(t)
+	if status := GetLexiconStatus(); status.Source != "static/lexicon.json" ||
+		len(status.Patterns) != len(RequiredLexicons) {
+		t.Errorf("wrong status of static lexicon %+v", status)
+	}
+
+	LexiconFile = filepath.Join(t.TempDir(), "lexicon.json")
+	writeTestLexicon(t, LexiconFile, "^/x$")
+	if err := LoadLexicon(); err != nil {
+		t.Fatal(err)
+	}
+	status := GetLexiconStatus()
+	if status.Source != LexiconFile || len(status.Patterns) != len(RequiredLexicons) {
+		t.Errorf("wrong status of lexicon file %+v", status)
+	}
+	for i := 1; i < len(status.Patterns); i++ {
+		if status.Patterns[i-1].Name > status.Patterns[i].Name {
+			t.Errorf("lexicon patterns are not sorted %+v", status.Patterns)
+		}
+	}
+	if err := CheckPattern("dataset", "/x"); err != nil {
+		t.Error(err)
+	}
+	if err := CheckPattern("dataset", "/a/b/c"); err == nil {
+		t.Error("dataset /a/b/c matches pattern of lexicon file")
+	}
+
+	// active lexicon is kept if new one is invalid
+	bad := []string{
+		`not json`,
+		`[{"name": "dataset", "patterns": ["^/x$"]}]`, // misses required patterns
+		`[{"name": "dataset", "patterns": ["(["]}]`,   // invalid regexp
+	}
+	for _, data := range bad {
+		if err := os.WriteFile(LexiconFile, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := LoadLexicon(); err == nil {
+			t.Errorf("lexicon %s is loaded", data)
+		}
+		if err := CheckPattern("dataset", "/x"); err != nil {
+			t.Errorf("active lexicon is replaced by %s: %v", data, err)
+		}
+	}
+	LexiconFile = filepath.Join(t.TempDir(), "missing.json")
+	if err := LoadLexicon(); err == nil {
+		t.Error("missing lexicon file is loaded")
+	}
+	if GetLexiconStatus().Source == LexiconFile {
+		t.Error("active lexicon is replaced by missing lexicon file")
+	}
+}
+
+// TestWatchLexicon tests reload of lexicon when lexicon file is changed
+func TestWatchLexicon(t *testing.T) {
+	loadTestLexicon(t)
+	LexiconFile = filepath.Join(t.TempDir(), "lexicon.json")
+	writeTestLexicon(t, LexiconFile, "^/x$")
+	if err := LoadLexicon(); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		WatchLexicon(ctx, 10*time.Millisecond)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// modification time is changed explicitly since file system may have
+	// coarse time resolution, it is advanced until watcher notices the change
+	// since watcher may start after the file is written
+	writeTestLexicon(t, LexiconFile, "^/y$")
+	mtime := time.Now()
+	deadline := mtime.Add(5 * time.Second)
+	for CheckPattern("dataset", "/y") != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("lexicon is not reloaded after lexicon file change")
+		}
+		mtime = mtime.Add(time.Second)
+		if err := os.Chtimes(LexiconFile, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err := CheckPattern("dataset", "/x"); err == nil {
+		t.Error("dataset /x matches pattern of reloaded lexicon")
+	}
+}
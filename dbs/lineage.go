@@ -0,0 +1,356 @@
+package dbs
+
+// nolint: gocyclo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/OreCast/DataBookkeeping/utils"
+)
+
+// LineageMaxDepth defines maximum depth of lineage graph traversal
+var LineageMaxDepth = 20
+
+// DatasetParents represents DatasetParents DBS DB table which keeps
+// many-to-many lineage relationship between datasets
+type DatasetParents struct {
+	THIS_DATASET_ID   int64  `json:"this_dataset_id" validate:"required,gt=0"`
+	PARENT_DATASET_ID int64  `json:"parent_dataset_id" validate:"required,gt=0"`
+	CREATION_DATE     int64  `json:"creation_date"`
+	CREATE_BY         string `json:"create_by"`
+}
+
+// ParentDatasets represents list of parent datasets of input dataset record.
+// It can be provided either as a single dataset name or list of dataset names.
+type ParentDatasets []string
+
+// UnmarshalJSON implements json.Unmarshaler interface for ParentDatasets
+func (p *ParentDatasets) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*p = nil
+		if name != "" {
+			*p = ParentDatasets{name}
+		}
+		return nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	*p = nil
+	for _, name := range names {
+		if name != "" {
+			*p = append(*p, name)
+		}
+	}
+	return nil
+}
+
+// Scan implements sql.Scanner interface for ParentDatasets, it reads comma
+// separated list of dataset names aggregated by DB
+func (p *ParentDatasets) Scan(src any) error {
+	*p = ParentDatasets{}
+	var names string
+	switch val := src.(type) {
+	case nil:
+		return nil
+	case string:
+		names = val
+	case []byte:
+		names = string(val)
+	default:
+		return fmt.Errorf("unsupported type %T of parent datasets", src)
+	}
+	for _, name := range strings.Split(names, ",") {
+		if name != "" {
+			*p = append(*p, name)
+		}
+	}
+	sort.Strings(*p)
+	return nil
+}
+
+// LineageEdge represents single edge of datasets lineage graph
+type LineageEdge struct {
+	Parent string `json:"parent"`
+	Child  string `json:"child"`
+	Depth  int    `json:"depth"`
+}
+
+// LineageNode represents single node of datasets lineage tree, repeated
+// node refers to dataset which is already expanded elsewhere in the tree
+type LineageNode struct {
+	Dataset  string         `json:"dataset"`
+	Repeated bool           `json:"repeated,omitempty"`
+	Parents  []*LineageNode `json:"parents,omitempty"`
+	Children []*LineageNode `json:"children,omitempty"`
+}
+
+// GetLineage API provides ancestors or descendants of given dataset.
+// It accepts direction (ancestors or descendants), depth and format
+// (tree or edges) parameters.
+func (a *API) GetLineage() error {
+	dataset, err := getSingleValue(a.Params, "dataset")
+	if err != nil {
+		return Error(err, ParametersErrorCode, "", "dbs.lineage.GetLineage")
+	}
+	direction := "ancestors"
+	if val, err := getSingleValue(a.Params, "direction"); err == nil {
+		direction = val
+	}
+	if direction != "ancestors" && direction != "descendants" {
+		msg := fmt.Sprintf("invalid direction '%s', should be ancestors or descendants", direction)
+		return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.lineage.GetLineage")
+	}
+	depth := LineageMaxDepth
+	if val, err := getSingleValue(a.Params, "depth"); err == nil {
+		depth, err = strconv.Atoi(val)
+		if err != nil || depth < 1 || depth > LineageMaxDepth {
+			msg := fmt.Sprintf("invalid depth '%s', should be within [1, %d]", val, LineageMaxDepth)
+			return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.lineage.GetLineage")
+		}
+	}
+	format := "tree"
+	if val, err := getSingleValue(a.Params, "format"); err == nil {
+		format = val
+	}
+	if format != "tree" && format != "edges" {
+		msg := fmt.Sprintf("invalid format '%s', should be tree or edges", format)
+		return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.lineage.GetLineage")
+	}
+	if utils.VERBOSE > 0 {
+		log.Printf("### /lineage dataset=%s direction=%s depth=%d format=%s",
+			dataset, direction, depth, format)
+	}
+
+	tx, err := BeginTx(a.Context)
+	if err != nil {
+		return Error(err, TransactionErrorCode, "", "dbs.lineage.GetLineage")
+	}
+	defer tx.Rollback()
+	if _, err = getDataset(tx, dataset); err != nil {
+		return err
+	}
+	edges, graph, err := lineage(tx, dataset, direction, depth)
+	if err != nil {
+		return Error(err, QueryErrorCode, "", "dbs.lineage.GetLineage")
+	}
+
+	if format == "edges" {
+		return writeRecords(a.Writer, a.Separator, edges)
+	}
+	tree := lineageTree(dataset, direction, graph, depth)
+	data, err := json.Marshal(tree)
+	if err != nil {
+		return Error(err, MarshalErrorCode, "", "dbs.lineage.GetLineage")
+	}
+	a.Writer.Write(data)
+	return nil
+}
+
+// helper function to write list of records to given writer either as
+// JSON list or as ndjson stream (when separator is empty)
+func writeRecords[T any](w io.Writer, sep string, records []T) error {
+	if sep == "" {
+		enc := json.NewEncoder(w)
+		for _, rec := range records {
+			if err := enc.Encode(rec); err != nil {
+				return Error(err, EncodeErrorCode, "", "dbs.writeRecords")
+			}
+		}
+		return nil
+	}
+	if records == nil {
+		records = []T{}
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return Error(err, MarshalErrorCode, "", "dbs.writeRecords")
+	}
+	w.Write(data)
+	return nil
+}
+
+// helper function to traverse lineage graph of given dataset up to given depth,
+// non-positive depth means full traversal. It returns list of graph edges
+// along with adjacency map of the traversed graph.
+func lineage(tx *Tx, dataset, direction string, depth int) ([]LineageEdge, map[string][]string, error) {
+	stm := getSQL("select_dataset_parents")
+	if direction == "descendants" {
+		stm = getSQL("select_dataset_children")
+	}
+	edges := []LineageEdge{}
+	graph := make(map[string][]string)
+	visited := map[string]bool{dataset: true}
+	level := []string{dataset}
+	for d := 1; (depth <= 0 || d <= depth) && len(level) > 0; d++ {
+		var next []string
+		for _, name := range level {
+			datasets, err := queryStrings(tx, stm, name)
+			if err != nil {
+				return edges, graph, err
+			}
+			graph[name] = datasets
+			for _, rel := range datasets {
+				edge := LineageEdge{Parent: rel, Child: name, Depth: d}
+				if direction == "descendants" {
+					edge = LineageEdge{Parent: name, Child: rel, Depth: d}
+				}
+				edges = append(edges, edge)
+				if !visited[rel] {
+					visited[rel] = true
+					next = append(next, rel)
+				}
+			}
+		}
+		level = next
+	}
+	return edges, graph, nil
+}
+
+// helper function to build lineage tree from given adjacency map. The tree
+// is built level by level and every dataset is expanded only once, its
+// further occurrences are provided by name and marked as repeated.
+func lineageTree(dataset, direction string, graph map[string][]string, depth int) *LineageNode {
+	root := &LineageNode{Dataset: dataset}
+	expanded := map[string]bool{dataset: true}
+	level := []*LineageNode{root}
+	for d := 0; d < depth && len(level) > 0; d++ {
+		var next []*LineageNode
+		for _, node := range level {
+			for _, rel := range graph[node.Dataset] {
+				sub := &LineageNode{Dataset: rel}
+				if expanded[rel] {
+					sub.Repeated = true
+				} else {
+					expanded[rel] = true
+					next = append(next, sub)
+				}
+				if direction == "descendants" {
+					node.Children = append(node.Children, sub)
+				} else {
+					node.Parents = append(node.Parents, sub)
+				}
+			}
+		}
+		level = next
+	}
+	return root
+}
+
+// helper function to set parents of given dataset. It replaces existing
+// lineage of the dataset with provided list of parent datasets.
+func setParents(tx *Tx, datasetId int64, dataset string, parents []string, createBy string) error {
+	stm := getSQL("delete_lineage_by_child")
+	if _, err := tx.Exec(stm, datasetId); err != nil {
+		return Error(err, RemoveErrorCode, "", "dbs.lineage.setParents")
+	}
+	if len(parents) == 0 {
+		return nil
+	}
+	// parent can't be dataset itself or any of its descendants at any depth
+	edges, _, err := lineage(tx, dataset, "descendants", 0)
+	if err != nil {
+		return err
+	}
+	descendants := []string{dataset}
+	for _, e := range edges {
+		descendants = append(descendants, e.Child)
+	}
+	for _, parent := range utils.Set(parents) {
+		if utils.InList(parent, descendants) {
+			msg := fmt.Sprintf("dataset %s can't be parent of %s, it creates lineage cycle", parent, dataset)
+			return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.lineage.setParents")
+		}
+		pid, err := GetID(tx, "DATASETS", "DATASET_ID", "dataset", parent)
+		if err != nil {
+			msg := fmt.Sprintf("parent dataset %s does not exist", parent)
+			return Error(err, DatasetParentDoesNotExist, msg, "dbs.lineage.setParents")
+		}
+		rec := DatasetParents{
+			THIS_DATASET_ID:   datasetId,
+			PARENT_DATASET_ID: pid,
+			CREATE_BY:         createBy,
+		}
+		if err = rec.Insert(tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Insert implementation of DatasetParents
+func (r *DatasetParents) Insert(tx *Tx) error {
+	// set defaults and validate the record
+	r.SetDefaults()
+	err := r.Validate()
+	if err != nil {
+		log.Println("unable to validate record", err)
+		return Error(err, ValidateErrorCode, "", "dbs.lineage.Insert")
+	}
+	// get SQL statement from static area
+	stm := getSQL("insert_dataset_parent")
+	if utils.VERBOSE > 1 {
+		log.Printf("Insert DatasetParents\n%s\n%+v", stm, r)
+	} else if utils.VERBOSE > 0 {
+		log.Printf("Insert DatasetParents record %+v", r)
+	}
+	_, err = tx.Exec(
+		stm,
+		r.THIS_DATASET_ID,
+		r.PARENT_DATASET_ID,
+		r.CREATION_DATE,
+		r.CREATE_BY)
+	if err != nil {
+		if utils.VERBOSE > 0 {
+			log.Println("unable to insert dataset parents, error", err)
+		}
+		return Error(err, InsertErrorCode, "", "dbs.lineage.Insert")
+	}
+	return nil
+}
+
+// Validate implementation of DatasetParents
+func (r *DatasetParents) Validate() error {
+	var errs ValidationErrors
+	errs.AddStruct(*r)
+	if r.THIS_DATASET_ID == r.PARENT_DATASET_ID {
+		// dataset can't be parent of itself
+		errs.Add("parent_dataset_id", r.PARENT_DATASET_ID, "nefield=this_dataset_id")
+	}
+	errs.AddDate("creation_date", r.CREATION_DATE)
+	return validationError(errs, "dbs.lineage.Validate")
+}
+
+// SetDefaults implements set defaults for DatasetParents
+func (r *DatasetParents) SetDefaults() {
+	if r.CREATE_BY == "" {
+		r.CREATE_BY = "Server"
+	}
+	if r.CREATION_DATE == 0 {
+		r.CREATION_DATE = Date()
+	}
+}
+
+// Decode implementation for DatasetParents
+func (r *DatasetParents) Decode(reader io.Reader) error {
+	// init record with given data record
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		log.Println("fail to read data", err)
+		return Error(err, ReaderErrorCode, "", "dbs.lineage.Decode")
+	}
+	err = json.Unmarshal(data, &r)
+	if err != nil {
+		log.Println("fail to decode data", err)
+		return Error(err, UnmarshalErrorCode, "", "dbs.lineage.Decode")
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package dbs
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// helper function to inject dataset with given parents
+func insertLineageDataset(t *testing.T, dataset string, parents ...string) {
+	t.Helper()
+	parent, err := json.Marshal(parents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	insertTestDataset(t, fmt.Sprintf(`{"dataset": "%s", "buckets": [], "site": "s1",
+		"processing": "p1", "meta_id": "m1", "parent_dataset": %s, "files": []}`, dataset, parent))
+}
+
+// helper function to get lineage tree of given dataset
+func getLineageTree(t *testing.T, params Record) LineageNode {
+	t.Helper()
+	var tree LineageNode
+	api, w := testApi(params, "")
+	if err := api.GetLineage(); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &tree); err != nil {
+		t.Fatal(err)
+	}
+	return tree
+}
+
+// helper function to count nodes of lineage tree
+func countNodes(node *LineageNode) int {
+	count := 1
+	for _, n := range append(node.Parents, node.Children...) {
+		count += countNodes(n)
+	}
+	return count
+}
+
+// TestLineageDiamonds tests that lineage tree of stacked diamond-shaped
+// graph expands every dataset only once
+func TestLineageDiamonds(t *testing.T) {
+	initTestDB(t)
+	// every level consists of two datasets which are children of both
+	// datasets of previous level
+	ndiamonds := 10
+	insertLineageDataset(t, "/l/d/0a")
+	insertLineageDataset(t, "/l/d/0b")
+	for i := 1; i <= ndiamonds; i++ {
+		p1, p2 := fmt.Sprintf("/l/d/%da", i-1), fmt.Sprintf("/l/d/%db", i-1)
+		insertLineageDataset(t, fmt.Sprintf("/l/d/%da", i), p1, p2)
+		insertLineageDataset(t, fmt.Sprintf("/l/d/%db", i), p1, p2)
+	}
+
+	records := getRecords(t, (*API).GetDataset, Record{"dataset": "/l/d/1a"})
+	if len(records) != 1 ||
+		!reflect.DeepEqual(records[0]["parent_dataset"], []any{"/l/d/0a", "/l/d/0b"}) {
+		t.Errorf("wrong parents of dataset %v", records)
+	}
+
+	dataset := fmt.Sprintf("/l/d/%da", ndiamonds)
+	tree := getLineageTree(t, Record{"dataset": dataset})
+	// every dataset is expanded once and has two parents, besides the root
+	// the tree has two nodes for every parent level
+	nodes := countNodes(&tree)
+	if nodes != 1+4*ndiamonds-2 {
+		t.Errorf("wrong number of lineage tree nodes %d", nodes)
+	}
+	expanded := make(map[string]int)
+	var walk func(node *LineageNode)
+	walk = func(node *LineageNode) {
+		if !node.Repeated {
+			expanded[node.Dataset]++
+		} else if len(node.Parents) != 0 {
+			t.Errorf("repeated dataset %s is expanded", node.Dataset)
+		}
+		for _, n := range node.Parents {
+			walk(n)
+		}
+	}
+	walk(&tree)
+	if len(expanded) != 2*ndiamonds+1 {
+		t.Errorf("wrong number of expanded datasets %d", len(expanded))
+	}
+	for name, count := range expanded {
+		if count != 1 {
+			t.Errorf("dataset %s is expanded %d times", name, count)
+		}
+	}
+
+	// descendants tree up to given depth
+	tree = getLineageTree(t, Record{"dataset": "/l/d/0a", "direction": "descendants", "depth": "2"})
+	var children []string
+	for _, n := range tree.Children {
+		children = append(children, n.Dataset)
+	}
+	sort.Strings(children)
+	if !reflect.DeepEqual(children, []string{"/l/d/1a", "/l/d/1b"}) || countNodes(&tree) != 7 {
+		t.Errorf("wrong descendants tree %+v", tree)
+	}
+
+	// edges are listed once per level
+	api, w := testApi(Record{"dataset": dataset, "format": "edges"}, "")
+	if err := api.GetLineage(); err != nil {
+		t.Fatal(err)
+	}
+	var edges []LineageEdge
+	if err := json.Unmarshal(w.Body.Bytes(), &edges); err != nil {
+		t.Fatal(err)
+	}
+	if len(edges) != 4*ndiamonds-2 {
+		t.Errorf("wrong number of lineage edges %d", len(edges))
+	}
+}
+
+// TestLineageCycle tests that lineage cycles are rejected regardless of
+// lineage depth
+func TestLineageCycle(t *testing.T) {
+	initTestDB(t)
+	maxDepth := LineageMaxDepth
+	LineageMaxDepth = 2
+	t.Cleanup(func() { LineageMaxDepth = maxDepth })
+
+	insertLineageDataset(t, "/c/d/0")
+	for i := 1; i <= 5; i++ {
+		insertLineageDataset(t, fmt.Sprintf("/c/d/%d", i), fmt.Sprintf("/c/d/%d", i-1))
+	}
+	err := updateTestDataset("/c/d/0", `{"parent_dataset": "/c/d/5"}`)
+	checkErrorCode(t, err, ParametersErrorCode)
+	err = updateTestDataset("/c/d/0", `{"parent_dataset": ["/c/d/0"]}`)
+	checkErrorCode(t, err, ParametersErrorCode)
+	if n := countParents(t, "/c/d/0"); n != 0 {
+		t.Errorf("lineage cycle is created with %d links", n)
+	}
+
+	api, _ := testApi(Record{"dataset": "/c/d/5", "depth": "3"}, "")
+	checkErrorCode(t, api.GetLineage(), ParametersErrorCode)
+}
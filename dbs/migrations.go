@@ -0,0 +1,321 @@
+package dbs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/OreCast/DataBookkeeping/utils"
+)
+
+// Migration represents single versioned schema migration. Migrations are
+// stored in migrations/<backend> area of static files as NNNN_name.up.sql
+// and NNNN_name.down.sql files.
+type Migration struct {
+	Version int    // migration version
+	Name    string // migration name
+	Up      string // file name of up migration
+	Down    string // file name of down migration
+}
+
+// Backend returns name of DB backend, e.g. sqlite or postgres
+func Backend() string {
+	if utils.ORACLE {
+		return "oracle"
+	} else if utils.POSTGRES {
+		return "postgres"
+	}
+	return "sqlite"
+}
+
+// helper function to get location of migrations of DB backend
+func migrationsDir() string {
+	return path.Join("migrations", Backend())
+}
+
+// helper function to check if DB backend has schema migrations
+func hasMigrations() bool {
+	_, err := fs.Stat(utils.StaticFS(), migrationsDir())
+	return err == nil
+}
+
+// Migrations returns ordered list of migrations of DB backend
+func Migrations() ([]Migration, error) {
+	mdir := migrationsDir()
+	files, err := utils.ListStaticFiles(mdir)
+	if err != nil {
+		msg := fmt.Sprintf("no migrations area %s", mdir)
+		return nil, Error(err, MigrationErrorCode, msg, "dbs.Migrations")
+	}
+	mmap := make(map[int]*Migration)
+	for _, fname := range files {
+		var direction string
+		if strings.HasSuffix(fname, ".up.sql") {
+			direction = "up"
+		} else if strings.HasSuffix(fname, ".down.sql") {
+			direction = "down"
+		} else {
+			continue
+		}
+		name := strings.TrimSuffix(fname, fmt.Sprintf(".%s.sql", direction))
+		arr := strings.SplitN(name, "_", 2)
+		version, err := strconv.Atoi(arr[0])
+		if err != nil || len(arr) != 2 || version < 1 {
+			msg := fmt.Sprintf("invalid migration file name %s, should be NNNN_name.%s.sql", fname, direction)
+			return nil, Error(InvalidParamErr, MigrationErrorCode, msg, "dbs.Migrations")
+		}
+		m, ok := mmap[version]
+		if !ok {
+			m = &Migration{Version: version, Name: arr[1]}
+			mmap[version] = m
+		}
+		if m.Name != arr[1] {
+			msg := fmt.Sprintf("migration version %d has different names %s and %s", version, m.Name, arr[1])
+			return nil, Error(InvalidParamErr, MigrationErrorCode, msg, "dbs.Migrations")
+		}
+		if direction == "up" {
+			m.Up = fname
+		} else {
+			m.Down = fname
+		}
+	}
+	var migrations []Migration
+	for _, m := range mmap {
+		if m.Up == "" {
+			msg := fmt.Sprintf("migration version %d does not have up migration", m.Version)
+			return nil, Error(InvalidParamErr, MigrationErrorCode, msg, "dbs.Migrations")
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			msg := fmt.Sprintf("migration version %d is missing", i+1)
+			return nil, Error(InvalidParamErr, MigrationErrorCode, msg, "dbs.Migrations")
+		}
+	}
+	return migrations, nil
+}
+
+// SchemaVersion returns current schema version of the database. The
+// database without schema_version table has version 0. The queries are
+// canceled when given context is done.
+func SchemaVersion(ctx context.Context) (int, error) {
+	tx, err := BeginTx(ctx)
+	if err != nil {
+		return 0, Error(err, TransactionErrorCode, "", "dbs.SchemaVersion")
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(getSQL("create_schema_version")); err != nil {
+		return 0, Error(err, MigrationErrorCode, "unable to create schema_version table", "dbs.SchemaVersion")
+	}
+	var version sql.NullInt64
+	if err := tx.QueryRow(getSQL("select_schema_version")).Scan(&version); err != nil {
+		return 0, Error(err, QueryErrorCode, "", "dbs.SchemaVersion")
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, Error(err, CommitErrorCode, "", "dbs.SchemaVersion")
+	}
+	return int(version.Int64), nil
+}
+
+// LatestSchemaVersion returns version of the latest available migration
+func LatestSchemaVersion() (int, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return 0, err
+	}
+	return len(migrations), nil
+}
+
+// CheckSchemaVersion checks that database schema is up to date with
+// available migrations
+func CheckSchemaVersion(ctx context.Context) error {
+	if !hasMigrations() {
+		log.Printf("WARNING: no migrations for %s backend, skip schema version check", Backend())
+		return nil
+	}
+	latest, err := LatestSchemaVersion()
+	if err != nil {
+		return err
+	}
+	version, err := SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if version < latest {
+		msg := fmt.Sprintf("database schema version %d is behind latest version %d, please run server with -migrate option", version, latest)
+		return Error(DatabaseErr, MigrationErrorCode, msg, "dbs.CheckSchemaVersion")
+	}
+	if version > latest {
+		log.Printf("WARNING: database schema version %d is ahead of latest known version %d", version, latest)
+	}
+	return nil
+}
+
+// Migrate migrates database schema to given version. The negative version
+// stands for the latest available version. If given version is lower than
+// current one the down migrations are applied.
+func Migrate(target int) error {
+	migrations, err := Migrations()
+	if err != nil {
+		return err
+	}
+	if target < 0 {
+		target = len(migrations)
+	}
+	if target > len(migrations) {
+		msg := fmt.Sprintf("unknown schema version %d, latest version is %d", target, len(migrations))
+		return Error(InvalidParamErr, MigrationErrorCode, msg, "dbs.Migrate")
+	}
+	version, err := SchemaVersion(context.Background())
+	if err != nil {
+		return err
+	}
+	if version > len(migrations) {
+		msg := fmt.Sprintf("database schema version %d is ahead of latest known version %d", version, len(migrations))
+		return Error(InvalidParamErr, MigrationErrorCode, msg, "dbs.Migrate")
+	}
+	// database created from initial schema before schema migrations were
+	// introduced does not have schema version, it is adopted as version 1
+	if version == 0 && target > 0 {
+		initial, err := hasInitialSchema()
+		if err != nil {
+			return err
+		}
+		if initial {
+			log.Printf("adopt existing %s database as schema version 1", Backend())
+			_, err := DB.Exec(getSQL("insert_schema_version"), migrations[0].Version, migrations[0].Name, Date())
+			if err != nil {
+				msg := "unable to record schema version of existing database"
+				return Error(err, MigrationErrorCode, msg, "dbs.Migrate")
+			}
+			version = 1
+		}
+	}
+	log.Printf("migrate %s database schema from version %d to %d", Backend(), version, target)
+	for version < target {
+		if err := migrate(migrations[version], "up"); err != nil {
+			return err
+		}
+		version++
+	}
+	for version > target {
+		if err := migrate(migrations[version-1], "down"); err != nil {
+			return err
+		}
+		version--
+	}
+	return nil
+}
+
+// helper function to check if database has tables of initial schema
+func hasInitialSchema() (bool, error) {
+	var stm string
+	var args []interface{}
+	if utils.POSTGRES {
+		stm = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = ? AND table_name = 'datasets'"
+		args = append(args, DBOWNER)
+	} else {
+		stm = "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'DATASETS'"
+	}
+	stm = Rebind(stm)
+	var ntables int64
+	if err := DB.QueryRow(stm, args...).Scan(&ntables); err != nil {
+		return false, Error(err, QueryErrorCode, "", "dbs.hasInitialSchema")
+	}
+	return ntables > 0, nil
+}
+
+// helper function to apply single migration in given direction within transaction
+func migrate(m Migration, direction string) error {
+	fname := m.Up
+	if direction == "down" {
+		fname = m.Down
+	}
+	if fname == "" {
+		msg := fmt.Sprintf("migration version %d does not have %s migration", m.Version, direction)
+		return Error(InvalidParamErr, MigrationErrorCode, msg, "dbs.migrate")
+	}
+	data, err := utils.ReadStaticFile(path.Join(migrationsDir(), fname))
+	if err != nil {
+		return Error(err, ReaderErrorCode, "", "dbs.migrate")
+	}
+	log.Printf("apply %s migration %s", direction, fname)
+	// use dedicated connection since SQLite foreign keys should be disabled
+	// while tables are rebuilt, and the pragma has no effect within transaction
+	ctx := context.Background()
+	conn, err := DB.Conn(ctx)
+	if err != nil {
+		return Error(err, DatabaseErrorCode, "", "dbs.migrate")
+	}
+	defer conn.Close()
+	if Backend() == "sqlite" {
+		if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys=OFF"); err != nil {
+			return Error(err, MigrationErrorCode, "", "dbs.migrate")
+		}
+		defer conn.ExecContext(ctx, "PRAGMA foreign_keys=ON")
+	}
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return Error(err, TransactionErrorCode, "", "dbs.migrate")
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(string(data)); err != nil {
+		msg := fmt.Sprintf("unable to apply migration %s", fname)
+		return Error(err, MigrationErrorCode, msg, "dbs.migrate")
+	}
+	if Backend() == "sqlite" {
+		if err := foreignKeyCheck(tx); err != nil {
+			msg := fmt.Sprintf("migration %s violates foreign keys", fname)
+			return Error(err, MigrationErrorCode, msg, "dbs.migrate")
+		}
+	}
+	if direction == "up" {
+		_, err = tx.Exec(getSQL("insert_schema_version"), m.Version, m.Name, Date())
+	} else {
+		_, err = tx.Exec(getSQL("delete_schema_version"), m.Version)
+	}
+	if err != nil {
+		msg := fmt.Sprintf("unable to update schema version of migration %s", fname)
+		return Error(err, MigrationErrorCode, msg, "dbs.migrate")
+	}
+	if err := tx.Commit(); err != nil {
+		return Error(err, CommitErrorCode, "", "dbs.migrate")
+	}
+	return nil
+}
+
+// helper function to check SQLite foreign keys of all tables
+func foreignKeyCheck(tx *sql.Tx) error {
+	rows, err := tx.Query("PRAGMA foreign_key_check")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	var violations []string
+	for rows.Next() {
+		var table, parent string
+		var rowid, fkid sql.NullInt64
+		if err := rows.Scan(&table, &rowid, &parent, &fkid); err != nil {
+			return err
+		}
+		violations = append(violations, fmt.Sprintf("%s row %d references missing %s", table, rowid.Int64, parent))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return errors.New(strings.Join(violations, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package dbs
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// helper function to get definitions of tables and indexes of SQLite database
+func schemaObjects(t *testing.T) []string {
+	t.Helper()
+	tx, err := BeginTx(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	stm := "SELECT sql FROM sqlite_master WHERE sql IS NOT NULL AND name NOT LIKE 'sqlite_%' ORDER BY name"
+	objects, err := queryStrings(tx, stm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return objects
+}
+
+// TestMigrateBaseline tests migration of database created from baseline
+// schema which predates schema migrations
+func TestMigrateBaseline(t *testing.T) {
+	initTestDB(t)
+	latest := schemaObjects(t)
+	if err := Migrate(0); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := os.ReadFile("testdata/baseline-sqlite-schema.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DB.Exec(string(schema)); err != nil {
+		t.Fatal(err)
+	}
+	// baseline records, dataset without parent has zero parent id
+	for _, stm := range []string{
+		"INSERT INTO SITES VALUES (1, 's1', 1700000000, 'test', 1700000000, 'test')",
+		"INSERT INTO PROCESSING VALUES (1, 'p1', 1700000000, 'test', 1700000000, 'test')",
+		"INSERT INTO DATASETS VALUES (1, '/a/b/c', 'm1', 1, 1, 0, 1700000000, 'test', 1700000000, 'test')",
+		"INSERT INTO BUCKETS VALUES (1, 'b1', 'm1', '1', 1700000000, 'test', 1700000000, 'test')",
+		"INSERT INTO FILES VALUES (1, '/a/f1', 1, 1, 'm1', 1700000000, 'test', 1700000000, 'test')",
+	} {
+		if _, err := DB.Exec(stm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := Migrate(-1); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckSchemaVersion(context.Background()); err != nil {
+		t.Error(err)
+	}
+	if objects := schemaObjects(t); !reflect.DeepEqual(objects, latest) {
+		t.Errorf("migrated baseline schema %v differs from latest schema %v", objects, latest)
+	}
+	records := getRecords(t, (*API).GetDataset, Record{"dataset": "/a/b/c"})
+	if len(records) != 1 || records[0]["dataset_access_type"] != "VALID" || records[0]["site"] != "s1" {
+		t.Errorf("wrong dataset after migrations %v", records)
+	}
+	buckets := recordValues(getRecords(t, (*API).GetBucket, Record{"dataset": "/a/b/c"}), "bucket")
+	if !reflect.DeepEqual(buckets, []string{"b1"}) {
+		t.Errorf("wrong dataset buckets after migrations %v", buckets)
+	}
+	records = getRecords(t, (*API).GetFile, Record{"dataset": "/a/b/c"})
+	if len(records) != 1 || records[0]["logical_file_name"] != "/a/f1" || records[0]["file_size"] != nil {
+		t.Errorf("wrong dataset files after migrations %v", records)
+	}
+	// new records get ids after ids of baseline records
+	insertTestDataset(t, `{"dataset": "/x/y/z", "buckets": ["b1"], "site": "s1",
+		"processing": "p1", "meta_id": "m1", "files": ["/x/f1"]}`)
+	records = getRecords(t, (*API).GetDataset, Record{"dataset": "/x/y/z"})
+	if len(records) != 1 || records[0]["dataset_id"] != 2.0 {
+		t.Errorf("wrong new dataset after migrations %v", records)
+	}
+}
+
+// TestMigrateDownUp tests that all migrations except the initial one can be
+// reverted and applied again on database with data
+func TestMigrateDownUp(t *testing.T) {
+	initTestDB(t)
+	insertTestDataset(t, testDataset)
+	if err := Migrate(1); err != nil {
+		t.Fatal(err)
+	}
+	if version, err := SchemaVersion(context.Background()); err != nil || version != 1 {
+		t.Fatalf("wrong schema version %d after down migrations, error %v", version, err)
+	}
+	if err := Migrate(-1); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckSchemaVersion(context.Background()); err != nil {
+		t.Error(err)
+	}
+	// existing datasets are valid after up migrations
+	records := getRecords(t, (*API).GetDataset, Record{"dataset": "/a/b/c"})
+	if len(records) != 1 || records[0]["dataset_access_type"] != "VALID" {
+		t.Errorf("wrong dataset after migrations %v", records)
+	}
+	files := recordValues(getRecords(t, (*API).GetFile, Record{"dataset": "/a/b/c"}), "logical_file_name")
+	if !reflect.DeepEqual(files, []string{"/a/f1", "/a/f2"}) {
+		t.Errorf("wrong dataset files after migrations %v", files)
+	}
+}
+
+// TestSchemaVersionContext tests that schema version queries are bound to
+// given context
+func TestSchemaVersionContext(t *testing.T) {
+	initTestDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := SchemaVersion(ctx); err == nil {
+		t.Error("schema version query should fail with canceled context")
+	}
+	if err := CheckSchemaVersion(ctx); err == nil {
+		t.Error("schema version check should fail with canceled context")
+	}
+	if err := GetTestData(ctx); err == nil {
+		t.Error("test query should fail with canceled context")
+	}
+}
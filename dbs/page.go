@@ -0,0 +1,284 @@
+package dbs
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/OreCast/DataBookkeeping/utils"
+)
+
+// PageLimit defines default number of records returned by GET APIs
+// when cursor is provided without limit
+var PageLimit = 1000
+
+// PageMaxLimit defines maximum number of records returned by GET APIs in a single page
+var PageMaxLimit = 10000
+
+// CursorHeader defines HTTP header which carries cursor of the next page
+const CursorHeader = "X-Next-Cursor"
+
+// Page represents keyset pagination of GET APIs. Records are ordered by
+// (optional) sort field and primary key, and the cursor keeps their values
+// of the last returned record.
+type Page struct {
+	Limit   int      // maximum number of records in a page
+	Key     string   // output column which holds primary key
+	SqlKey  string   // SQL column name of primary key
+	Sort    *Sort    // sorting of the records
+	Next    string   // cursor of the next page
+	Records []Record // records of the page
+	last    Record   // last added record
+}
+
+// Sort represents ordering of GET API results by given output field
+type Sort struct {
+	Key    string // output field
+	SqlKey string // SQL column name of output field
+	Desc   bool   // descending order
+}
+
+// NextCursor represents trailing ndjson record with cursor of the next page
+type NextCursor struct {
+	Cursor string `json:"next_cursor"`
+}
+
+// cursor represents content of opaque cursor token
+type cursor struct {
+	Key   string      `json:"key"`
+	Id    int64       `json:"id"`
+	Sort  string      `json:"sort,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// GetFields parses fields parameter, e.g. ?fields=dataset,site and checks
+// it against given whitelist of API output fields. It returns nil if all
+// fields should be provided.
+func GetFields(params Record, whitelist map[string]string) ([]string, error) {
+	var fields []string
+	for _, val := range getValues(params, "fields") {
+		for _, field := range strings.Split(val, ",") {
+			field = strings.Trim(field, " ")
+			if field == "" {
+				continue
+			}
+			if _, ok := whitelist[field]; !ok {
+				msg := fmt.Sprintf("invalid field '%s', allowed fields: %s", field, mapKeys(whitelist))
+				return nil, Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.GetFields")
+			}
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+// GetSort parses sort parameter, e.g. ?sort=-creation_date, and checks it
+// against given list of sortable fields. The leading minus sign defines
+// descending order. It returns nil if sorting is not requested.
+func GetSort(params Record, whitelist map[string]string, sortable []string) (*Sort, error) {
+	val, _ := getSingleValue(params, "sort")
+	if val == "" {
+		return nil, nil
+	}
+	order := &Sort{Key: strings.TrimPrefix(val, "-"), Desc: strings.HasPrefix(val, "-")}
+	if !utils.InList(order.Key, sortable) {
+		msg := fmt.Sprintf("invalid sort field '%s', allowed fields: %s", order.Key, strings.Join(sortable, ","))
+		return nil, Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.GetSort")
+	}
+	order.SqlKey = whitelist[order.Key]
+	return order, nil
+}
+
+// AddPage parses limit and cursor parameters and adds keyset condition to
+// given set of conditions. It returns nil page if pagination is not requested.
+func AddPage(
+	key, sqlName string,
+	order *Sort,
+	params Record,
+	conds []string,
+	args []interface{}) (*Page, []string, []interface{}, error) {
+
+	limit, _ := getSingleValue(params, "limit")
+	token, _ := getSingleValue(params, "cursor")
+	if limit == "" && token == "" {
+		return nil, conds, args, nil
+	}
+	page := &Page{Limit: PageLimit, Key: key, SqlKey: sqlName, Sort: order}
+	if limit != "" {
+		val, err := strconv.Atoi(limit)
+		if err != nil || val < 1 || val > PageMaxLimit {
+			msg := fmt.Sprintf("invalid limit '%s', should be within [1, %d]", limit, PageMaxLimit)
+			return nil, conds, args, Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.AddPage")
+		}
+		page.Limit = val
+	}
+	if token == "" {
+		return page, conds, args, nil
+	}
+	c, err := page.decodeCursor(token)
+	if err != nil {
+		return nil, conds, args, err
+	}
+	if order == nil {
+		cond := fmt.Sprintf(" %s > %s", sqlName, placeholder("cursor"))
+		conds = append(conds, cond)
+		args = append(args, c.Id)
+		return page, conds, args, nil
+	}
+	op := ">"
+	if order.Desc {
+		op = "<"
+	}
+	cond := fmt.Sprintf(" (%s %s %s OR (%s = %s AND %s > %s))",
+		order.SqlKey, op, placeholder("sort_value"),
+		order.SqlKey, placeholder("sort_value"),
+		sqlName, placeholder("cursor"))
+	conds = append(conds, cond)
+	args = append(args, c.Value, c.Value, c.Id)
+	return page, conds, args, nil
+}
+
+// OrderClause adds ordering and limit clauses to given statement. The primary
+// key is used as tie-breaker of sorting to provide stable ordering of pages.
+// We query one extra record to find out if the next page exists.
+func OrderClause(stm string, order *Sort, page *Page) string {
+	var keys []string
+	if order != nil {
+		if order.Desc {
+			keys = append(keys, fmt.Sprintf("%s DESC", order.SqlKey))
+		} else {
+			keys = append(keys, order.SqlKey)
+		}
+	}
+	if page != nil {
+		keys = append(keys, page.SqlKey)
+	}
+	if len(keys) == 0 {
+		return stm
+	}
+	stm = fmt.Sprintf("%s\nORDER BY %s", stm, strings.Join(keys, ", "))
+	if page == nil {
+		return stm
+	}
+	if utils.ORACLE {
+		return fmt.Sprintf("%s\nFETCH FIRST %d ROWS ONLY", stm, page.Limit+1)
+	}
+	return fmt.Sprintf("%s\nLIMIT %d", stm, page.Limit+1)
+}
+
+// Add adds record to the page using given output fields. It returns false
+// when page is full, in which case the cursor of the next page is set.
+func (p *Page) Add(rec Record, fields []string) bool {
+	if len(p.Records) < p.Limit {
+		p.last = rec
+		p.Records = append(p.Records, projectRecord(rec, fields))
+		return true
+	}
+	p.Next = p.encodeCursor(p.last)
+	return false
+}
+
+// Write writes page records to given writer. The cursor of the next page is
+// provided in response header and, for ndjson format, as trailing record.
+func (p *Page) Write(w io.Writer, sep string) error {
+	if hw, ok := w.(http.ResponseWriter); ok && p.Next != "" {
+		hw.Header().Set(CursorHeader, p.Next)
+	}
+	if err := writeRecords(w, sep, p.Records); err != nil {
+		return err
+	}
+	if sep == "" && p.Next != "" {
+		return json.NewEncoder(w).Encode(NextCursor{Cursor: p.Next})
+	}
+	return nil
+}
+
+// helper function to encode cursor for given record
+func (p *Page) encodeCursor(rec Record) string {
+	c := cursor{Key: p.Key, Id: cursorValue(rec[p.Key])}
+	if p.Sort != nil {
+		c.Sort = p.Sort.Key
+		c.Value = rec[p.Sort.Key]
+		if val, ok := c.Value.([]byte); ok {
+			c.Value = string(val)
+		}
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		log.Println("unable to encode cursor", err)
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// helper function to decode cursor token
+func (p *Page) decodeCursor(token string) (cursor, error) {
+	var c cursor
+	msg := fmt.Sprintf("invalid cursor '%s'", token)
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, Error(err, ParametersErrorCode, msg, "dbs.decodeCursor")
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, Error(err, ParametersErrorCode, msg, "dbs.decodeCursor")
+	}
+	sortKey := ""
+	if p.Sort != nil {
+		sortKey = p.Sort.Key
+	}
+	if c.Key != p.Key || c.Sort != sortKey {
+		msg = fmt.Sprintf("%s, cursor does not match API or sort parameter", msg)
+		return c, Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.decodeCursor")
+	}
+	// JSON numbers are decoded as float64, keep integer values as int64
+	if val, ok := c.Value.(float64); ok && val == float64(int64(val)) {
+		c.Value = int64(val)
+	}
+	return c, nil
+}
+
+// helper function to convert primary key value to int64
+func cursorValue(val interface{}) int64 {
+	switch v := val.(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	case []byte:
+		id, _ := strconv.ParseInt(string(v), 10, 64)
+		return id
+	default:
+		id, _ := strconv.ParseInt(fmt.Sprintf("%v", v), 10, 64)
+		return id
+	}
+}
+
+// helper function to project record to given output fields
+func projectRecord(rec Record, fields []string) Record {
+	if len(fields) == 0 {
+		return rec
+	}
+	out := make(Record, len(fields))
+	for _, field := range fields {
+		if val, ok := rec[field]; ok {
+			out[field] = val
+		}
+	}
+	return out
+}
+
+// helper function to provide sorted comma separated list of map keys
+func mapKeys(m map[string]string) string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
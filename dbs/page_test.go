@@ -0,0 +1,157 @@
+package dbs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// helper function to inject dataset with given number of files /p/fN
+func insertPageDataset(t *testing.T, nfiles int) {
+	t.Helper()
+	var files []string
+	for i := 0; i < nfiles; i++ {
+		files = append(files, fmt.Sprintf(`{"logical_file_name": "/p/f%d", "file_size": %d}`, i, (i%3)*100))
+	}
+	insertTestDataset(t, `{"dataset": "/p/q/r", "buckets": [], "site": "s1", "processing": "p1",
+		"meta_id": "m1", "files": [`+strings.Join(files, ",")+`]}`)
+}
+
+// helper function to fetch all pages of files API following cursor
+// provided in response header, it returns file names of every page
+func getFilePages(t *testing.T, params Record) [][]string {
+	t.Helper()
+	var pages [][]string
+	for {
+		api, w := testApi(params, "")
+		if err := api.GetFile(); err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, rec := range decodeRecords(t, w) {
+			names = append(names, fmt.Sprintf("%v", rec["logical_file_name"]))
+		}
+		pages = append(pages, names)
+		next := w.Header().Get(CursorHeader)
+		if next == "" {
+			return pages
+		}
+		params["cursor"] = next
+		if len(pages) > 100 {
+			t.Fatal("pagination does not stop")
+		}
+	}
+}
+
+// TestCursorPagination tests keyset pagination of files API
+func TestCursorPagination(t *testing.T) {
+	initTestDB(t)
+	insertPageDataset(t, 5)
+
+	pages := getFilePages(t, Record{"dataset": "/p/q/r", "limit": "2"})
+	got := fmt.Sprintf("%v", pages)
+	if got != "[[/p/f0 /p/f1] [/p/f2 /p/f3] [/p/f4]]" {
+		t.Errorf("wrong pages %s", got)
+	}
+	// the page which ends exactly at last record does not provide cursor
+	pages = getFilePages(t, Record{"dataset": "/p/q/r", "limit": "5"})
+	if len(pages) != 1 || len(pages[0]) != 5 {
+		t.Errorf("wrong single page %v", pages)
+	}
+
+	// ndjson output provides cursor as trailing record
+	api, w := testApi(Record{"dataset": "/p/q/r", "limit": "3"}, "")
+	api.Separator = ""
+	if err := api.GetFile(); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	var next NextCursor
+	if len(lines) != 4 || json.Unmarshal([]byte(lines[3]), &next) != nil ||
+		next.Cursor == "" || next.Cursor != w.Header().Get(CursorHeader) {
+		t.Fatalf("wrong ndjson page %v", lines)
+	}
+	pages = getFilePages(t, Record{"dataset": "/p/q/r", "cursor": next.Cursor})
+	if fmt.Sprintf("%v", pages) != "[[/p/f3 /p/f4]]" {
+		t.Errorf("wrong page of ndjson cursor %v", pages)
+	}
+
+	// invalid limit and cursors
+	for _, params := range []Record{
+		{"limit": "0"},
+		{"limit": fmt.Sprintf("%d", PageMaxLimit+1)},
+		{"limit": "x"},
+		{"cursor": "bogus"},
+		{"cursor": next.Cursor, "sort": "creation_date"},
+	} {
+		params["dataset"] = "/p/q/r"
+		api, _ := testApi(params, "")
+		checkErrorCode(t, api.GetFile(), ParametersErrorCode)
+	}
+	// cursor of files API can't be used by datasets API
+	api, _ = testApi(Record{"cursor": next.Cursor}, "")
+	checkErrorCode(t, api.GetDataset(), ParametersErrorCode)
+}
+
+// TestFieldProjection tests fields parameter of GET APIs
+func TestFieldProjection(t *testing.T) {
+	initTestDB(t)
+	insertTestDataset(t, testDataset)
+
+	records := getRecords(t, (*API).GetDataset, Record{"fields": "dataset, site"})
+	if len(records) != 1 || len(records[0]) != 2 ||
+		records[0]["dataset"] != "/a/b/c" || records[0]["site"] != "s1" {
+		t.Errorf("wrong projected datasets %v", records)
+	}
+	// projection is applied to paginated records as well
+	records = getRecords(t, (*API).GetFile, Record{"dataset": "/a/b/c", "fields": "logical_file_name", "limit": "1"})
+	if len(records) != 1 || len(records[0]) != 1 || records[0]["logical_file_name"] != "/a/f1" {
+		t.Errorf("wrong projected files %v", records)
+	}
+	records = getRecords(t, (*API).GetSite, Record{"fields": "site"})
+	if len(records) != 1 || len(records[0]) != 1 {
+		t.Errorf("wrong projected sites %v", records)
+	}
+	api, _ := testApi(Record{"fields": "dataset,bogus"}, "")
+	checkErrorCode(t, api.GetDataset(), ParametersErrorCode)
+}
+
+// TestSort tests sort parameter of GET APIs along with pagination of
+// sorted records
+func TestSort(t *testing.T) {
+	initTestDB(t)
+	insertPageDataset(t, 6)
+
+	records := getRecords(t, (*API).GetFile, Record{"dataset": "/p/q/r", "sort": "-logical_file_name"})
+	var names []string
+	for _, rec := range records {
+		names = append(names, fmt.Sprintf("%v", rec["logical_file_name"]))
+	}
+	if strings.Join(names, " ") != "/p/f5 /p/f4 /p/f3 /p/f2 /p/f1 /p/f0" {
+		t.Errorf("wrong order of files %v", names)
+	}
+
+	// pages of records sorted by non-unique field are ordered by primary
+	// key within the same value of sort field
+	payload := `{"logical_file_names": ["/p/f1", "/p/f4"], "is_file_valid": 0}`
+	if _, err := updateTestFiles(t, nil, payload); err != nil {
+		t.Fatal(err)
+	}
+	pages := getFilePages(t, Record{"dataset": "/p/q/r", "sort": "is_file_valid", "limit": "4"})
+	got := fmt.Sprintf("%v", pages)
+	if got != "[[/p/f1 /p/f4 /p/f0 /p/f2] [/p/f3 /p/f5]]" {
+		t.Errorf("wrong pages of sorted files %s", got)
+	}
+
+	pages = getFilePages(t, Record{"dataset": "/p/q/r", "sort": "-is_file_valid", "limit": "4"})
+	got = fmt.Sprintf("%v", pages)
+	if got != "[[/p/f0 /p/f2 /p/f3 /p/f5] [/p/f1 /p/f4]]" {
+		t.Errorf("wrong pages of files in descending order %s", got)
+	}
+
+	for _, sort := range []string{"bogus", "-meta_id"} {
+		api, _ := testApi(Record{"dataset": "/p/q/r", "sort": sort}, "")
+		checkErrorCode(t, api.GetFile(), ParametersErrorCode)
+	}
+}
@@ -22,6 +22,19 @@ type Parents struct {
 	LAST_MODIFIED_BY       string `json:"last_modified_by"`
 }
 
+// parentFields defines output fields of parents API along with their SQL columns
+var parentFields = map[string]string{
+	"parent_id":              "P.PARENT_ID",
+	"parent":                 "P.PARENT",
+	"creation_date":          "P.CREATION_DATE",
+	"create_by":              "P.CREATE_BY",
+	"last_modification_date": "P.LAST_MODIFICATION_DATE",
+	"last_modified_by":       "P.LAST_MODIFIED_BY",
+}
+
+// parentSortFields defines output fields of parents API which can be used for sorting
+var parentSortFields = []string{"parent_id", "parent", "creation_date", "last_modification_date"}
+
 // Parents DBS API
 //
 //gocyclo:ignore
@@ -30,6 +43,33 @@ func (a *API) GetParent() error {
 	var conds []string
 	var err error
 
+	if val, ok := a.Params["parent"]; ok {
+		if val != "" {
+			conds, args = AddParam("parent", "P.PARENT", a.Params, conds, args)
+		}
+	}
+	if val, ok := a.Params["dataset"]; ok {
+		if val != "" {
+			conds, args = AddParam("dataset", "D.DATASET", a.Params, conds, args)
+		}
+	}
+	fields, err := GetFields(a.Params, parentFields)
+	if err != nil {
+		return err
+	}
+	order, err := GetSort(a.Params, parentFields, parentSortFields)
+	if err != nil {
+		return err
+	}
+	var page *Page
+	page, conds, args, err = AddPage("parent_id", "P.PARENT_ID", order, a.Params, conds, args)
+	if err != nil {
+		return err
+	}
+	if utils.VERBOSE > 0 {
+		log.Println("### /parent params", a.Params, conds, args)
+	}
+
 	tmpl := make(Record)
 	tmpl["Owner"] = DBOWNER
 	stm, err := LoadTemplateSQL("select_parent", tmpl)
@@ -37,10 +77,10 @@ func (a *API) GetParent() error {
 		return Error(err, LoadErrorCode, "", "dbs.parents.Parents")
 	}
 
-	stm = WhereClause(stm, conds)
+	stm = OrderClause(WhereClause(stm, conds), order, page)
 
 	// use generic query API to fetch the results from DB
-	err = executeAll(a.Writer, a.Separator, stm, args...)
+	err = executeAll(a.Context, a.Writer, a.Separator, fields, page, stm, args...)
 	if err != nil {
 		return Error(err, QueryErrorCode, "", "dbs.parents.Parents")
 	}
@@ -51,21 +91,114 @@ func (a *API) GetParent() error {
 func (a *API) InsertParent() error {
 	// the API provides Reader which will be used by Decode function to load the HTTP payload
 	// and cast it to Parents data structure
-	return insertRecord(&Parents{}, a.Reader)
+	return insertRecord(a.Context, &Parents{}, a.Reader)
 }
 
-// UpdateParent inserts parent record in DB
+// UpdateParent updates parent record in DB, the attributes provided in
+// HTTP payload overwrite ones of existing parent record
 func (a *API) UpdateParent() error {
+	name, err := getSingleValue(a.Params, "parent")
+	if err != nil {
+		return Error(err, ParametersErrorCode, "", "dbs.parents.UpdateParent")
+	}
+	data, err := io.ReadAll(a.Reader)
+	if err != nil {
+		log.Println("fail to read data", err)
+		return Error(err, ReaderErrorCode, "", "dbs.parents.UpdateParent")
+	}
+
+	// start transaction
+	tx, err := BeginTx(a.Context)
+	if err != nil {
+		return Error(err, TransactionErrorCode, "", "dbs.parents.UpdateParent")
+	}
+	defer tx.Rollback()
+	rec, err := getParent(tx, name)
+	if err != nil {
+		return err
+	}
+	rid := rec.PARENT_ID
+	err = json.Unmarshal(data, &rec)
+	if err != nil {
+		log.Println("fail to decode data", err)
+		return Error(err, UnmarshalErrorCode, "", "dbs.parents.UpdateParent")
+	}
+	rec.PARENT_ID = rid
+	rec.LAST_MODIFICATION_DATE = Date()
+	rec.LAST_MODIFIED_BY = a.CreateBy
+	if err = rec.Update(tx); err != nil {
+		return Error(err, UpdateErrorCode, "", "dbs.parents.UpdateParent")
+	}
+	err = tx.Commit()
+	if err != nil {
+		return Error(err, CommitErrorCode, "", "dbs.parents.UpdateParent")
+	}
 	return nil
 }
 
 // DeleteParent deletes parent record in DB
 func (a *API) DeleteParent() error {
+	name, err := getSingleValue(a.Params, "parent")
+	if err != nil {
+		return Error(err, ParametersErrorCode, "", "dbs.parents.DeleteParent")
+	}
+
+	// start transaction
+	tx, err := BeginTx(a.Context)
+	if err != nil {
+		return Error(err, TransactionErrorCode, "", "dbs.parents.DeleteParent")
+	}
+	defer tx.Rollback()
+	rid, err := GetID(tx, "PARENTS", "PARENT_ID", "parent", name)
+	if err != nil {
+		msg := fmt.Sprintf("parent %s does not exist", name)
+		return Error(err, GetIDErrorCode, msg, "dbs.parents.DeleteParent")
+	}
+	// parent used by datasets is only removed with force flag, in such case
+	// it is unlinked from the datasets
+	force := getBool(a.Params, "force")
+	if err = unlinkDatasets(tx, "parent", name, rid, force, "update_parent_datasets"); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(getSQL("delete_parent"), rid); err != nil {
+		return Error(err, RemoveErrorCode, "", "dbs.parents.DeleteParent")
+	}
+	err = tx.Commit()
+	if err != nil {
+		return Error(err, CommitErrorCode, "", "dbs.parents.DeleteParent")
+	}
 	return nil
 }
 
+// helper function to fetch parent record for given parent name
+func getParent(tx *Tx, parent string) (Parents, error) {
+	var rec Parents
+	var createBy, modifiedBy sql.NullString
+	var cdate, mdate sql.NullInt64
+	stm := getSQL("select_parent_record")
+	err := tx.QueryRow(stm, parent).Scan(
+		&rec.PARENT_ID,
+		&rec.PARENT,
+		&cdate,
+		&createBy,
+		&mdate,
+		&modifiedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			msg := fmt.Sprintf("parent %s does not exist", parent)
+			return rec, Error(err, GetIDErrorCode, msg, "dbs.parents.getParent")
+		}
+		return rec, Error(err, QueryErrorCode, "", "dbs.parents.getParent")
+	}
+	rec.CREATION_DATE = cdate.Int64
+	rec.CREATE_BY = createBy.String
+	rec.LAST_MODIFICATION_DATE = mdate.Int64
+	rec.LAST_MODIFIED_BY = modifiedBy.String
+	return rec, nil
+}
+
 // Insert implementation of Parents
-func (r *Parents) Insert(tx *sql.Tx) error {
+func (r *Parents) Insert(tx *Tx) error {
 	var err error
 	if r.PARENT_ID == 0 {
 		parentID, err := getNextId(tx, "PARENTS", "PARENT_ID")
@@ -84,14 +217,14 @@ func (r *Parents) Insert(tx *sql.Tx) error {
 	}
 	// get SQL statement from static area
 	stm := getSQL("insert_parent")
-	if utils.VERBOSE > 0 {
-		log.Printf("Insert Parents record %+v", r)
-	} else if utils.VERBOSE > 1 {
+	if utils.VERBOSE > 1 {
 		log.Printf("Insert Parents\n%s\n%+v", stm, r)
+	} else if utils.VERBOSE > 0 {
+		log.Printf("Insert Parents record %+v", r)
 	}
-	_, err = tx.Exec(
+	res, err := tx.Exec(
 		stm,
-		r.PARENT_ID,
+		nullId(r.PARENT_ID),
 		r.PARENT,
 		r.CREATION_DATE,
 		r.CREATE_BY,
@@ -103,25 +236,51 @@ func (r *Parents) Insert(tx *sql.Tx) error {
 		}
 		return Error(err, InsertErrorCode, "", "dbs.parents.Insert")
 	}
-	return nil
+	// obtain id of the record if it was assigned by DB
+	r.PARENT_ID, err = insertedId(res, r.PARENT_ID)
+	return err
 }
 
-// Validate implementation of Parents
-func (r *Parents) Validate() error {
-	if err := RecordValidator.Struct(*r); err != nil {
-		return DecodeValidatorError(r, err)
+// Update implementation of Parents
+func (r *Parents) Update(tx *Tx) error {
+	// set defaults and validate the record
+	r.SetDefaults()
+	err := r.Validate()
+	if err != nil {
+		log.Println("unable to validate record", err)
+		return Error(err, ValidateErrorCode, "", "dbs.parents.Update")
 	}
-	if matched := unixTimePattern.MatchString(fmt.Sprintf("%d", r.CREATION_DATE)); !matched {
-		msg := "invalid pattern for creation date"
-		return Error(InvalidParamErr, PatternErrorCode, msg, "dbs.parents.Validate")
+	// get SQL statement from static area
+	stm := getSQL("update_parent")
+	if utils.VERBOSE > 1 {
+		log.Printf("Update Parents\n%s\n%+v", stm, r)
+	} else if utils.VERBOSE > 0 {
+		log.Printf("Update Parents record %+v", r)
 	}
-	if matched := unixTimePattern.MatchString(fmt.Sprintf("%d", r.LAST_MODIFICATION_DATE)); !matched {
-		msg := "invalid pattern for last modification date"
-		return Error(InvalidParamErr, PatternErrorCode, msg, "dbs.parents.Validate")
+	_, err = tx.Exec(
+		stm,
+		r.PARENT,
+		r.LAST_MODIFICATION_DATE,
+		r.LAST_MODIFIED_BY,
+		r.PARENT_ID)
+	if err != nil {
+		if utils.VERBOSE > 0 {
+			log.Println("unable to update parents, error", err)
+		}
+		return Error(err, UpdateErrorCode, "", "dbs.parents.Update")
 	}
 	return nil
 }
 
+// Validate implementation of Parents
+func (r *Parents) Validate() error {
+	var errs ValidationErrors
+	errs.AddStruct(*r)
+	errs.AddDate("creation_date", r.CREATION_DATE)
+	errs.AddDate("last_modification_date", r.LAST_MODIFICATION_DATE)
+	return validationError(errs, "dbs.parents.Validate")
+}
+
 // SetDefaults implements set defaults for Parents
 func (r *Parents) SetDefaults() {
 	if r.CREATE_BY == "" {
@@ -0,0 +1,12 @@
+package dbs
+
+import "testing"
+
+// TestParentAPI tests parents APIs
+func TestParentAPI(t *testing.T) {
+	r := recordAPI{"parent", (*API).GetParent, (*API).InsertParent,
+		(*API).UpdateParent, (*API).DeleteParent, true}
+	child := `{"dataset": "/a/b/d", "buckets": [], "site": "s1", "processing": "p1",
+		"meta_id": "m1", "parent_dataset": "/a/b/c", "files": []}`
+	testRecordAPI(t, r, "/a/b/d", "/a/b/c", testDataset, child)
+}
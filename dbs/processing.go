@@ -22,6 +22,19 @@ type Processing struct {
 	LAST_MODIFIED_BY       string `json:"last_modified_by"`
 }
 
+// processingFields defines output fields of processing API along with their SQL columns
+var processingFields = map[string]string{
+	"processing_id":          "PR.PROCESSING_ID",
+	"processing":             "PR.PROCESSING",
+	"creation_date":          "PR.CREATION_DATE",
+	"create_by":              "PR.CREATE_BY",
+	"last_modification_date": "PR.LAST_MODIFICATION_DATE",
+	"last_modified_by":       "PR.LAST_MODIFIED_BY",
+}
+
+// processingSortFields defines output fields of processing API which can be used for sorting
+var processingSortFields = []string{"processing_id", "processing", "creation_date", "last_modification_date"}
+
 // Processing DBS API
 //
 //gocyclo:ignore
@@ -30,6 +43,33 @@ func (a *API) GetProcessing() error {
 	var conds []string
 	var err error
 
+	if val, ok := a.Params["processing"]; ok {
+		if val != "" {
+			conds, args = AddParam("processing", "PR.PROCESSING", a.Params, conds, args)
+		}
+	}
+	if val, ok := a.Params["dataset"]; ok {
+		if val != "" {
+			conds, args = AddParam("dataset", "D.DATASET", a.Params, conds, args)
+		}
+	}
+	fields, err := GetFields(a.Params, processingFields)
+	if err != nil {
+		return err
+	}
+	order, err := GetSort(a.Params, processingFields, processingSortFields)
+	if err != nil {
+		return err
+	}
+	var page *Page
+	page, conds, args, err = AddPage("processing_id", "PR.PROCESSING_ID", order, a.Params, conds, args)
+	if err != nil {
+		return err
+	}
+	if utils.VERBOSE > 0 {
+		log.Println("### /processing params", a.Params, conds, args)
+	}
+
 	tmpl := make(Record)
 	tmpl["Owner"] = DBOWNER
 	stm, err := LoadTemplateSQL("select_processing", tmpl)
@@ -37,10 +77,10 @@ func (a *API) GetProcessing() error {
 		return Error(err, LoadErrorCode, "", "dbs.processing.Processing")
 	}
 
-	stm = WhereClause(stm, conds)
+	stm = OrderClause(WhereClause(stm, conds), order, page)
 
 	// use generic query API to fetch the results from DB
-	err = executeAll(a.Writer, a.Separator, stm, args...)
+	err = executeAll(a.Context, a.Writer, a.Separator, fields, page, stm, args...)
 	if err != nil {
 		return Error(err, QueryErrorCode, "", "dbs.processing.Processing")
 	}
@@ -51,21 +91,113 @@ func (a *API) GetProcessing() error {
 func (a *API) InsertProcessing() error {
 	// the API provides Reader which will be used by Decode function to load the HTTP payload
 	// and cast it to Processing data structure
-	return insertRecord(&Processing{}, a.Reader)
+	return insertRecord(a.Context, &Processing{}, a.Reader)
 }
 
-// UpdateProcessing inserts processing record in DB
+// UpdateProcessing updates processing record in DB, the attributes provided in
+// HTTP payload overwrite ones of existing processing record
 func (a *API) UpdateProcessing() error {
+	name, err := getSingleValue(a.Params, "processing")
+	if err != nil {
+		return Error(err, ParametersErrorCode, "", "dbs.processing.UpdateProcessing")
+	}
+	data, err := io.ReadAll(a.Reader)
+	if err != nil {
+		log.Println("fail to read data", err)
+		return Error(err, ReaderErrorCode, "", "dbs.processing.UpdateProcessing")
+	}
+
+	// start transaction
+	tx, err := BeginTx(a.Context)
+	if err != nil {
+		return Error(err, TransactionErrorCode, "", "dbs.processing.UpdateProcessing")
+	}
+	defer tx.Rollback()
+	rec, err := getProcessing(tx, name)
+	if err != nil {
+		return err
+	}
+	rid := rec.PROCESSING_ID
+	err = json.Unmarshal(data, &rec)
+	if err != nil {
+		log.Println("fail to decode data", err)
+		return Error(err, UnmarshalErrorCode, "", "dbs.processing.UpdateProcessing")
+	}
+	rec.PROCESSING_ID = rid
+	rec.LAST_MODIFICATION_DATE = Date()
+	rec.LAST_MODIFIED_BY = a.CreateBy
+	if err = rec.Update(tx); err != nil {
+		return Error(err, UpdateErrorCode, "", "dbs.processing.UpdateProcessing")
+	}
+	err = tx.Commit()
+	if err != nil {
+		return Error(err, CommitErrorCode, "", "dbs.processing.UpdateProcessing")
+	}
 	return nil
 }
 
 // DeleteProcessing deletes processing record in DB
 func (a *API) DeleteProcessing() error {
+	name, err := getSingleValue(a.Params, "processing")
+	if err != nil {
+		return Error(err, ParametersErrorCode, "", "dbs.processing.DeleteProcessing")
+	}
+
+	// start transaction
+	tx, err := BeginTx(a.Context)
+	if err != nil {
+		return Error(err, TransactionErrorCode, "", "dbs.processing.DeleteProcessing")
+	}
+	defer tx.Rollback()
+	rid, err := GetID(tx, "PROCESSING", "PROCESSING_ID", "processing", name)
+	if err != nil {
+		msg := fmt.Sprintf("processing %s does not exist", name)
+		return Error(err, GetIDErrorCode, msg, "dbs.processing.DeleteProcessing")
+	}
+	// processing used by datasets can not be removed since datasets require it
+	force := getBool(a.Params, "force")
+	if err = unlinkDatasets(tx, "processing", name, rid, force, ""); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(getSQL("delete_processing"), rid); err != nil {
+		return Error(err, RemoveErrorCode, "", "dbs.processing.DeleteProcessing")
+	}
+	err = tx.Commit()
+	if err != nil {
+		return Error(err, CommitErrorCode, "", "dbs.processing.DeleteProcessing")
+	}
 	return nil
 }
 
+// helper function to fetch processing record for given processing name
+func getProcessing(tx *Tx, processing string) (Processing, error) {
+	var rec Processing
+	var createBy, modifiedBy sql.NullString
+	var cdate, mdate sql.NullInt64
+	stm := getSQL("select_processing_record")
+	err := tx.QueryRow(stm, processing).Scan(
+		&rec.PROCESSING_ID,
+		&rec.PROCESSING,
+		&cdate,
+		&createBy,
+		&mdate,
+		&modifiedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			msg := fmt.Sprintf("processing %s does not exist", processing)
+			return rec, Error(err, GetIDErrorCode, msg, "dbs.processing.getProcessing")
+		}
+		return rec, Error(err, QueryErrorCode, "", "dbs.processing.getProcessing")
+	}
+	rec.CREATION_DATE = cdate.Int64
+	rec.CREATE_BY = createBy.String
+	rec.LAST_MODIFICATION_DATE = mdate.Int64
+	rec.LAST_MODIFIED_BY = modifiedBy.String
+	return rec, nil
+}
+
 // Insert implementation of Processing
-func (r *Processing) Insert(tx *sql.Tx) error {
+func (r *Processing) Insert(tx *Tx) error {
 	var err error
 	if r.PROCESSING_ID == 0 {
 		processingID, err := getNextId(tx, "PROCESSING", "PROCESSING_ID")
@@ -84,14 +216,14 @@ func (r *Processing) Insert(tx *sql.Tx) error {
 	}
 	// get SQL statement from static area
 	stm := getSQL("insert_processing")
-	if utils.VERBOSE > 0 {
-		log.Printf("Insert Processing record %+v", r)
-	} else if utils.VERBOSE > 1 {
+	if utils.VERBOSE > 1 {
 		log.Printf("Insert Processing\n%s\n%+v", stm, r)
+	} else if utils.VERBOSE > 0 {
+		log.Printf("Insert Processing record %+v", r)
 	}
-	_, err = tx.Exec(
+	res, err := tx.Exec(
 		stm,
-		r.PROCESSING_ID,
+		nullId(r.PROCESSING_ID),
 		r.PROCESSING,
 		r.CREATION_DATE,
 		r.CREATE_BY,
@@ -103,25 +235,52 @@ func (r *Processing) Insert(tx *sql.Tx) error {
 		}
 		return Error(err, InsertErrorCode, "", "dbs.processing.Insert")
 	}
-	return nil
+	// obtain id of the record if it was assigned by DB
+	r.PROCESSING_ID, err = insertedId(res, r.PROCESSING_ID)
+	return err
 }
 
-// Validate implementation of Processing
-func (r *Processing) Validate() error {
-	if err := RecordValidator.Struct(*r); err != nil {
-		return DecodeValidatorError(r, err)
+// Update implementation of Processing
+func (r *Processing) Update(tx *Tx) error {
+	// set defaults and validate the record
+	r.SetDefaults()
+	err := r.Validate()
+	if err != nil {
+		log.Println("unable to validate record", err)
+		return Error(err, ValidateErrorCode, "", "dbs.processing.Update")
 	}
-	if matched := unixTimePattern.MatchString(fmt.Sprintf("%d", r.CREATION_DATE)); !matched {
-		msg := "invalid pattern for creation date"
-		return Error(InvalidParamErr, PatternErrorCode, msg, "dbs.processing.Validate")
+	// get SQL statement from static area
+	stm := getSQL("update_processing")
+	if utils.VERBOSE > 1 {
+		log.Printf("Update Processing\n%s\n%+v", stm, r)
+	} else if utils.VERBOSE > 0 {
+		log.Printf("Update Processing record %+v", r)
 	}
-	if matched := unixTimePattern.MatchString(fmt.Sprintf("%d", r.LAST_MODIFICATION_DATE)); !matched {
-		msg := "invalid pattern for last modification date"
-		return Error(InvalidParamErr, PatternErrorCode, msg, "dbs.processing.Validate")
+	_, err = tx.Exec(
+		stm,
+		r.PROCESSING,
+		r.LAST_MODIFICATION_DATE,
+		r.LAST_MODIFIED_BY,
+		r.PROCESSING_ID)
+	if err != nil {
+		if utils.VERBOSE > 0 {
+			log.Println("unable to update processing, error", err)
+		}
+		return Error(err, UpdateErrorCode, "", "dbs.processing.Update")
 	}
 	return nil
 }
 
+// Validate implementation of Processing
+func (r *Processing) Validate() error {
+	var errs ValidationErrors
+	errs.AddStruct(*r)
+	errs.AddPattern("processing", "processing", r.PROCESSING)
+	errs.AddDate("creation_date", r.CREATION_DATE)
+	errs.AddDate("last_modification_date", r.LAST_MODIFICATION_DATE)
+	return validationError(errs, "dbs.processing.Validate")
+}
+
 // SetDefaults implements set defaults for Processing
 func (r *Processing) SetDefaults() {
 	if r.CREATE_BY == "" {
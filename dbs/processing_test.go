@@ -0,0 +1,10 @@
+package dbs
+
+import "testing"
+
+// TestProcessingAPI tests processing APIs
+func TestProcessingAPI(t *testing.T) {
+	r := recordAPI{"processing", (*API).GetProcessing, (*API).InsertProcessing,
+		(*API).UpdateProcessing, (*API).DeleteProcessing, false}
+	testRecordAPI(t, r, "/a/b/c", "p1", testDataset)
+}
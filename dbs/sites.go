@@ -22,6 +22,19 @@ type Sites struct {
 	LAST_MODIFIED_BY       string `json:"last_modified_by"`
 }
 
+// siteFields defines output fields of sites API along with their SQL columns
+var siteFields = map[string]string{
+	"site_id":                "S.SITE_ID",
+	"site":                   "S.SITE",
+	"creation_date":          "S.CREATION_DATE",
+	"create_by":              "S.CREATE_BY",
+	"last_modification_date": "S.LAST_MODIFICATION_DATE",
+	"last_modified_by":       "S.LAST_MODIFIED_BY",
+}
+
+// siteSortFields defines output fields of sites API which can be used for sorting
+var siteSortFields = []string{"site_id", "site", "creation_date", "last_modification_date"}
+
 // Sites DBS API
 //
 //gocyclo:ignore
@@ -30,6 +43,33 @@ func (a *API) GetSite() error {
 	var conds []string
 	var err error
 
+	if val, ok := a.Params["site"]; ok {
+		if val != "" {
+			conds, args = AddParam("site", "S.SITE", a.Params, conds, args)
+		}
+	}
+	if val, ok := a.Params["dataset"]; ok {
+		if val != "" {
+			conds, args = AddParam("dataset", "D.DATASET", a.Params, conds, args)
+		}
+	}
+	fields, err := GetFields(a.Params, siteFields)
+	if err != nil {
+		return err
+	}
+	order, err := GetSort(a.Params, siteFields, siteSortFields)
+	if err != nil {
+		return err
+	}
+	var page *Page
+	page, conds, args, err = AddPage("site_id", "S.SITE_ID", order, a.Params, conds, args)
+	if err != nil {
+		return err
+	}
+	if utils.VERBOSE > 0 {
+		log.Println("### /site params", a.Params, conds, args)
+	}
+
 	tmpl := make(Record)
 	tmpl["Owner"] = DBOWNER
 	stm, err := LoadTemplateSQL("select_site", tmpl)
@@ -37,10 +77,10 @@ func (a *API) GetSite() error {
 		return Error(err, LoadErrorCode, "", "dbs.sites.Sites")
 	}
 
-	stm = WhereClause(stm, conds)
+	stm = OrderClause(WhereClause(stm, conds), order, page)
 
 	// use generic query API to fetch the results from DB
-	err = executeAll(a.Writer, a.Separator, stm, args...)
+	err = executeAll(a.Context, a.Writer, a.Separator, fields, page, stm, args...)
 	if err != nil {
 		return Error(err, QueryErrorCode, "", "dbs.sites.Sites")
 	}
@@ -51,21 +91,113 @@ func (a *API) GetSite() error {
 func (a *API) InsertSite() error {
 	// the API provides Reader which will be used by Decode function to load the HTTP payload
 	// and cast it to Sites data structure
-	return insertRecord(&Sites{}, a.Reader)
+	return insertRecord(a.Context, &Sites{}, a.Reader)
 }
 
-// UpdateSite inserts site record in DB
+// UpdateSite updates site record in DB, the attributes provided in
+// HTTP payload overwrite ones of existing site record
 func (a *API) UpdateSite() error {
+	name, err := getSingleValue(a.Params, "site")
+	if err != nil {
+		return Error(err, ParametersErrorCode, "", "dbs.sites.UpdateSite")
+	}
+	data, err := io.ReadAll(a.Reader)
+	if err != nil {
+		log.Println("fail to read data", err)
+		return Error(err, ReaderErrorCode, "", "dbs.sites.UpdateSite")
+	}
+
+	// start transaction
+	tx, err := BeginTx(a.Context)
+	if err != nil {
+		return Error(err, TransactionErrorCode, "", "dbs.sites.UpdateSite")
+	}
+	defer tx.Rollback()
+	rec, err := getSite(tx, name)
+	if err != nil {
+		return err
+	}
+	rid := rec.SITE_ID
+	err = json.Unmarshal(data, &rec)
+	if err != nil {
+		log.Println("fail to decode data", err)
+		return Error(err, UnmarshalErrorCode, "", "dbs.sites.UpdateSite")
+	}
+	rec.SITE_ID = rid
+	rec.LAST_MODIFICATION_DATE = Date()
+	rec.LAST_MODIFIED_BY = a.CreateBy
+	if err = rec.Update(tx); err != nil {
+		return Error(err, UpdateErrorCode, "", "dbs.sites.UpdateSite")
+	}
+	err = tx.Commit()
+	if err != nil {
+		return Error(err, CommitErrorCode, "", "dbs.sites.UpdateSite")
+	}
 	return nil
 }
 
 // DeleteSite deletes site record in DB
 func (a *API) DeleteSite() error {
+	name, err := getSingleValue(a.Params, "site")
+	if err != nil {
+		return Error(err, ParametersErrorCode, "", "dbs.sites.DeleteSite")
+	}
+
+	// start transaction
+	tx, err := BeginTx(a.Context)
+	if err != nil {
+		return Error(err, TransactionErrorCode, "", "dbs.sites.DeleteSite")
+	}
+	defer tx.Rollback()
+	rid, err := GetID(tx, "SITES", "SITE_ID", "site", name)
+	if err != nil {
+		msg := fmt.Sprintf("site %s does not exist", name)
+		return Error(err, GetIDErrorCode, msg, "dbs.sites.DeleteSite")
+	}
+	// site used by datasets can not be removed since datasets require it
+	force := getBool(a.Params, "force")
+	if err = unlinkDatasets(tx, "site", name, rid, force, ""); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(getSQL("delete_site"), rid); err != nil {
+		return Error(err, RemoveErrorCode, "", "dbs.sites.DeleteSite")
+	}
+	err = tx.Commit()
+	if err != nil {
+		return Error(err, CommitErrorCode, "", "dbs.sites.DeleteSite")
+	}
 	return nil
 }
 
+// helper function to fetch site record for given site name
+func getSite(tx *Tx, site string) (Sites, error) {
+	var rec Sites
+	var createBy, modifiedBy sql.NullString
+	var cdate, mdate sql.NullInt64
+	stm := getSQL("select_site_record")
+	err := tx.QueryRow(stm, site).Scan(
+		&rec.SITE_ID,
+		&rec.SITE,
+		&cdate,
+		&createBy,
+		&mdate,
+		&modifiedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			msg := fmt.Sprintf("site %s does not exist", site)
+			return rec, Error(err, GetIDErrorCode, msg, "dbs.sites.getSite")
+		}
+		return rec, Error(err, QueryErrorCode, "", "dbs.sites.getSite")
+	}
+	rec.CREATION_DATE = cdate.Int64
+	rec.CREATE_BY = createBy.String
+	rec.LAST_MODIFICATION_DATE = mdate.Int64
+	rec.LAST_MODIFIED_BY = modifiedBy.String
+	return rec, nil
+}
+
 // Insert implementation of Sites
-func (r *Sites) Insert(tx *sql.Tx) error {
+func (r *Sites) Insert(tx *Tx) error {
 	var err error
 	if r.SITE_ID == 0 {
 		siteID, err := getNextId(tx, "SITES", "SITE_ID")
@@ -84,14 +216,14 @@ func (r *Sites) Insert(tx *sql.Tx) error {
 	}
 	// get SQL statement from static area
 	stm := getSQL("insert_site")
-	if utils.VERBOSE > 0 {
-		log.Printf("Insert Sites record %+v", r)
-	} else if utils.VERBOSE > 1 {
+	if utils.VERBOSE > 1 {
 		log.Printf("Insert Sites\n%s\n%+v", stm, r)
+	} else if utils.VERBOSE > 0 {
+		log.Printf("Insert Sites record %+v", r)
 	}
-	_, err = tx.Exec(
+	res, err := tx.Exec(
 		stm,
-		r.SITE_ID,
+		nullId(r.SITE_ID),
 		r.SITE,
 		r.CREATION_DATE,
 		r.CREATE_BY,
@@ -103,25 +235,52 @@ func (r *Sites) Insert(tx *sql.Tx) error {
 		}
 		return Error(err, InsertErrorCode, "", "dbs.sites.Insert")
 	}
-	return nil
+	// obtain id of the record if it was assigned by DB
+	r.SITE_ID, err = insertedId(res, r.SITE_ID)
+	return err
 }
 
-// Validate implementation of Sites
-func (r *Sites) Validate() error {
-	if err := RecordValidator.Struct(*r); err != nil {
-		return DecodeValidatorError(r, err)
+// Update implementation of Sites
+func (r *Sites) Update(tx *Tx) error {
+	// set defaults and validate the record
+	r.SetDefaults()
+	err := r.Validate()
+	if err != nil {
+		log.Println("unable to validate record", err)
+		return Error(err, ValidateErrorCode, "", "dbs.sites.Update")
 	}
-	if matched := unixTimePattern.MatchString(fmt.Sprintf("%d", r.CREATION_DATE)); !matched {
-		msg := "invalid pattern for creation date"
-		return Error(InvalidParamErr, PatternErrorCode, msg, "dbs.sites.Validate")
+	// get SQL statement from static area
+	stm := getSQL("update_site")
+	if utils.VERBOSE > 1 {
+		log.Printf("Update Sites\n%s\n%+v", stm, r)
+	} else if utils.VERBOSE > 0 {
+		log.Printf("Update Sites record %+v", r)
 	}
-	if matched := unixTimePattern.MatchString(fmt.Sprintf("%d", r.LAST_MODIFICATION_DATE)); !matched {
-		msg := "invalid pattern for last modification date"
-		return Error(InvalidParamErr, PatternErrorCode, msg, "dbs.sites.Validate")
+	_, err = tx.Exec(
+		stm,
+		r.SITE,
+		r.LAST_MODIFICATION_DATE,
+		r.LAST_MODIFIED_BY,
+		r.SITE_ID)
+	if err != nil {
+		if utils.VERBOSE > 0 {
+			log.Println("unable to update sites, error", err)
+		}
+		return Error(err, UpdateErrorCode, "", "dbs.sites.Update")
 	}
 	return nil
 }
 
+// Validate implementation of Sites
+func (r *Sites) Validate() error {
+	var errs ValidationErrors
+	errs.AddStruct(*r)
+	errs.AddPattern("site", "site", r.SITE)
+	errs.AddDate("creation_date", r.CREATION_DATE)
+	errs.AddDate("last_modification_date", r.LAST_MODIFICATION_DATE)
+	return validationError(errs, "dbs.sites.Validate")
+}
+
 // SetDefaults implements set defaults for Sites
 func (r *Sites) SetDefaults() {
 	if r.CREATE_BY == "" {
@@ -0,0 +1,106 @@
+package dbs
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// recordAPI defines DBS APIs of named records, e.g. sites or processing
+type recordAPI struct {
+	name   string           // name of the record attribute
+	get    func(*API) error // GET API
+	insert func(*API) error // POST API
+	update func(*API) error // PUT API
+	delete func(*API) error // DELETE API
+	unlink bool             // forced removal unlinks record from datasets
+}
+
+// helper function to call record API with given parameters and payload
+func callRecordAPI(api func(*API) error, params Record, payload string) error {
+	a, _ := testApi(params, payload)
+	return api(a)
+}
+
+// helper function to test life cycle of named record via its DBS APIs,
+// the record used by given dataset is called used and it is only deleted
+// with force flag if it can be unlinked from the dataset
+func testRecordAPI(t *testing.T, r recordAPI, dataset, used string, payloads ...string) {
+	t.Helper()
+	initTestDB(t)
+	for _, payload := range payloads {
+		insertTestDataset(t, payload)
+	}
+
+	if err := callRecordAPI(r.insert, nil, `{"`+r.name+`": "new"}`); err != nil {
+		t.Fatal(err)
+	}
+	names := recordValues(getRecords(t, r.get, nil), r.name)
+	expect := []string{"new", used}
+	sort.Strings(expect)
+	if !reflect.DeepEqual(names, expect) {
+		t.Errorf("wrong %s records %v", r.name, names)
+	}
+	// filter records by name and by dataset
+	names = recordValues(getRecords(t, r.get, Record{r.name: "ne*"}), r.name)
+	if !reflect.DeepEqual(names, []string{"new"}) {
+		t.Errorf("wrong %s records matching pattern %v", r.name, names)
+	}
+	names = recordValues(getRecords(t, r.get, Record{"dataset": dataset}), r.name)
+	if !reflect.DeepEqual(names, []string{used}) {
+		t.Errorf("wrong %s records of dataset %v", r.name, names)
+	}
+
+	// rename record and remove it
+	params := Record{r.name: "new"}
+	if err := callRecordAPI(r.update, params, `{"`+r.name+`": "renamed"}`); err != nil {
+		t.Fatal(err)
+	}
+	records := getRecords(t, r.get, Record{r.name: "renamed"})
+	if len(records) != 1 || records[0]["last_modified_by"] != "test" {
+		t.Errorf("%s record is not updated: %v", r.name, records)
+	}
+	// update keeps attributes which are not provided in payload
+	if err := callRecordAPI(r.update, Record{r.name: "renamed"}, `{}`); err != nil {
+		t.Fatal(err)
+	}
+	if records = getRecords(t, r.get, Record{r.name: "renamed"}); len(records) != 1 {
+		t.Errorf("%s record is not kept by update: %v", r.name, records)
+	}
+	checkErrorCode(t, callRecordAPI(r.delete, params, ""), GetIDErrorCode)
+	if err := callRecordAPI(r.delete, Record{r.name: "renamed"}, ""); err != nil {
+		t.Fatal(err)
+	}
+	names = recordValues(getRecords(t, r.get, nil), r.name)
+	if !reflect.DeepEqual(names, []string{used}) {
+		t.Errorf("%s record is not removed: %v", r.name, names)
+	}
+
+	checkErrorCode(t, callRecordAPI(r.insert, nil, `{"`+r.name+`": ""}`), ValidateErrorCode)
+
+	// records used by datasets are only removed with force flag
+	params = Record{r.name: used}
+	checkErrorCode(t, callRecordAPI(r.delete, params, ""), RecordInUseErrorCode)
+	params["force"] = "true"
+	err := callRecordAPI(r.delete, params, "")
+	if !r.unlink {
+		checkErrorCode(t, err, RecordInUseErrorCode)
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	names = recordValues(getRecords(t, r.get, nil), r.name)
+	if len(names) != 0 {
+		t.Errorf("%s record is not removed: %v", r.name, names)
+	}
+	if records = getRecords(t, (*API).GetDataset, Record{"dataset": dataset}); len(records) != 1 {
+		t.Errorf("dataset is removed along with %s record: %v", r.name, records)
+	}
+}
+
+// TestSiteAPI tests sites APIs
+func TestSiteAPI(t *testing.T) {
+	r := recordAPI{"site", (*API).GetSite, (*API).InsertSite, (*API).UpdateSite, (*API).DeleteSite, false}
+	testRecordAPI(t, r, "/a/b/c", "s1", testDataset)
+}
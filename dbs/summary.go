@@ -0,0 +1,153 @@
+package dbs
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+
+	"github.com/OreCast/DataBookkeeping/utils"
+)
+
+// DatasetSummary represents summary information about dataset and its files
+type DatasetSummary struct {
+	Dataset           string   `json:"dataset"`
+	Site              string   `json:"site"`
+	Processing        string   `json:"processing"`
+	Buckets           []string `json:"buckets"`
+	NumberOfFiles     int64    `json:"nfiles"`
+	NumberOfValid     int64    `json:"nvalid_files"`
+	TotalSize         int64    `json:"total_size"`
+	FirstCreationDate int64    `json:"first_creation_date,omitempty"`
+	LastCreationDate  int64    `json:"last_creation_date,omitempty"`
+}
+
+// GetDatasetSummary API provides summary of dataset(s) matching given dataset
+// parameter (wildcards are allowed). The summary is aggregated in DB using
+// datasets join of select_dataset statement.
+func (a *API) GetDatasetSummary() error {
+	if utils.VERBOSE > 1 {
+		log.Printf("datasetsummary params %+v", a.Params)
+	}
+	var args []interface{}
+	var conds []string
+	tmpl := make(Record)
+	tmpl["Owner"] = DBOWNER
+	tmpl["ParentDatasets"] = listAgg("PD.DATASET")
+
+	if _, err := getSingleValue(a.Params, "dataset"); err != nil {
+		return Error(err, ParametersErrorCode, "", "dbs.summary.GetDatasetSummary")
+	}
+	conds, args = AddParam("dataset", "D.DATASET", a.Params, conds, args)
+	if utils.VERBOSE > 0 {
+		log.Println("### /datasetsummary params", a.Params, conds, args)
+	}
+
+	// get SQL statements from static area, the datasets statement is used
+	// as sub-query of summary statements
+	stm, err := LoadTemplateSQL("select_dataset", tmpl)
+	if err != nil {
+		return Error(err, LoadErrorCode, "", "dbs.summary.GetDatasetSummary")
+	}
+	tmpl["Datasets"] = WhereClause(stm, conds)
+	stm, err = LoadTemplateSQL("select_dataset_summary", tmpl)
+	if err != nil {
+		return Error(err, LoadErrorCode, "", "dbs.summary.GetDatasetSummary")
+	}
+	bstm, err := LoadTemplateSQL("select_dataset_summary_buckets", tmpl)
+	if err != nil {
+		return Error(err, LoadErrorCode, "", "dbs.summary.GetDatasetSummary")
+	}
+	stm = Rebind(CleanStatement(stm))
+	bstm = Rebind(CleanStatement(bstm))
+	if DRYRUN {
+		utils.PrintSQL(stm, args, "")
+		utils.PrintSQL(bstm, args, "")
+		return nil
+	}
+
+	tx, err := BeginTx(a.Context)
+	if err != nil {
+		return Error(err, TransactionErrorCode, "", "dbs.summary.GetDatasetSummary")
+	}
+	defer tx.Rollback()
+	records, err := datasetSummary(tx, stm, bstm, args...)
+	if err != nil {
+		return Error(err, QueryErrorCode, "", "dbs.summary.GetDatasetSummary")
+	}
+	if len(records) == 0 {
+		// report non-existing dataset unless wildcard look-up is used
+		dataset, _ := getSingleValue(a.Params, "dataset")
+		if !strings.Contains(dataset, "*") {
+			if _, err := getDataset(tx, dataset); err != nil {
+				return err
+			}
+		}
+	}
+	return writeRecords(a.Writer, a.Separator, records)
+}
+
+// helper function to query dataset summary records along with their buckets
+func datasetSummary(tx *Tx, stm, bstm string, args ...interface{}) ([]DatasetSummary, error) {
+	var records []DatasetSummary
+	if utils.VERBOSE > 1 {
+		utils.PrintSQL(stm, args, "execute")
+	}
+	rows, err := tx.Query(stm, args...)
+	if err != nil {
+		return records, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var dataset, site, processing sql.NullString
+		var nfiles, nvalid, size, first, last sql.NullInt64
+		err = rows.Scan(&dataset, &site, &processing, &nfiles, &nvalid, &size, &first, &last)
+		if err != nil {
+			return records, err
+		}
+		rec := DatasetSummary{
+			Dataset:           dataset.String,
+			Site:              site.String,
+			Processing:        processing.String,
+			Buckets:           []string{},
+			NumberOfFiles:     nfiles.Int64,
+			NumberOfValid:     nvalid.Int64,
+			TotalSize:         size.Int64,
+			FirstCreationDate: first.Int64,
+			LastCreationDate:  last.Int64,
+		}
+		records = append(records, rec)
+	}
+	if err = rows.Err(); err != nil {
+		return records, err
+	}
+	if len(records) == 0 {
+		return records, nil
+	}
+
+	// collect buckets of all datasets
+	if utils.VERBOSE > 1 {
+		utils.PrintSQL(bstm, args, "execute")
+	}
+	brows, err := tx.Query(bstm, args...)
+	if err != nil {
+		return records, err
+	}
+	defer brows.Close()
+	buckets := make(map[string][]string)
+	for brows.Next() {
+		var dataset, bucket sql.NullString
+		if err = brows.Scan(&dataset, &bucket); err != nil {
+			return records, err
+		}
+		buckets[dataset.String] = append(buckets[dataset.String], bucket.String)
+	}
+	if err = brows.Err(); err != nil {
+		return records, err
+	}
+	for i := range records {
+		if val, ok := buckets[records[i].Dataset]; ok {
+			records[i].Buckets = val
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,66 @@
+package dbs
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// helper function to get dataset summary records
+func getSummary(t *testing.T, dataset string) []DatasetSummary {
+	t.Helper()
+	var records []DatasetSummary
+	api, w := testApi(Record{"dataset": dataset}, "")
+	if err := api.GetDatasetSummary(); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &records); err != nil {
+		t.Fatal(err)
+	}
+	return records
+}
+
+// TestDatasetSummary tests summary of single dataset and wildcard look-up
+func TestDatasetSummary(t *testing.T) {
+	initTestDB(t)
+	insertTestDataset(t, `{"dataset": "/a/b/c", "buckets": ["b2", "b1"], "site": "s1",
+		"processing": "p1", "meta_id": "m1", "files": [
+		{"logical_file_name": "/a/f1", "file_size": 100},
+		{"logical_file_name": "/a/f2", "file_size": 200}, "/a/f3"]}`)
+	insertTestDataset(t, `{"dataset": "/a/b/d", "buckets": [], "site": "s2",
+		"processing": "p2", "meta_id": "m1", "files": []}`)
+	if _, err := updateTestFiles(t, Record{"logical_file_name": "/a/f3"}, `{"is_file_valid": 0}`); err != nil {
+		t.Fatal(err)
+	}
+
+	records := getSummary(t, "/a/b/c")
+	if len(records) != 1 {
+		t.Fatalf("expected single summary record, got %+v", records)
+	}
+	rec := records[0]
+	if rec.Dataset != "/a/b/c" || rec.Site != "s1" || rec.Processing != "p1" ||
+		rec.NumberOfFiles != 3 || rec.NumberOfValid != 2 || rec.TotalSize != 300 {
+		t.Errorf("wrong dataset summary %+v", rec)
+	}
+	if !reflect.DeepEqual(rec.Buckets, []string{"b1", "b2"}) {
+		t.Errorf("wrong summary buckets %v", rec.Buckets)
+	}
+	if rec.FirstCreationDate == 0 || rec.FirstCreationDate > rec.LastCreationDate {
+		t.Errorf("wrong creation dates of summary %+v", rec)
+	}
+
+	// wildcard look-up includes datasets without files
+	records = getSummary(t, "/a/*")
+	if len(records) != 2 || records[1].Dataset != "/a/b/d" ||
+		records[1].NumberOfFiles != 0 || len(records[1].Buckets) != 0 {
+		t.Errorf("wrong summary of datasets %+v", records)
+	}
+	if records = getSummary(t, "/x/*"); len(records) != 0 {
+		t.Errorf("wrong summary of non-existing datasets %+v", records)
+	}
+
+	api, _ := testApi(Record{"dataset": "/x/y/z"}, "")
+	checkErrorCode(t, api.GetDatasetSummary(), DatasetDoesNotExist)
+	api, _ = testApi(nil, "")
+	checkErrorCode(t, api.GetDatasetSummary(), ParametersErrorCode)
+}
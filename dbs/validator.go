@@ -3,53 +3,83 @@ package dbs
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net/http"
+	"os"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/OreCast/DataBookkeeping/utils"
+	validator "github.com/go-playground/validator/v10"
 )
 
-// DBS string parameters
-var strParameters = []string{
-	"dataset",
-	"block_name",
-	"parent_dataset",
-	"release_version",
-	"pset_hash",
-	"app_name",
-	"output_module_label",
-	"global_tag",
-	"processing_version",
-	"acquisition_era_name",
-	"physics_group_name",
-	"logical_file_name",
-	"primary_ds_name",
-	"primary_ds_type",
-	"processed_ds_name",
-	"data_tier_name",
-	"dataset_access_type",
-	"create_by",
-	"user",
-	"last_modified_by",
+// types of DBS API parameters
+const (
+	StrType  = "str"  // string value, it may contain wildcards
+	IntType  = "int"  // non-negative integer value
+	MixType  = "mix"  // integer value or range of integers, e.g. 10-20
+	BoolType = "bool" // boolean value, e.g. true or 1
+	FlagType = "flag" // flag value, either 0 or 1
+)
+
+// Parameters represents HTTP query parameters of DBS API along with their types
+type Parameters map[string]string
+
+// helper function to add paging parameters to parameters of DBS API
+func withPaging(params Parameters) Parameters {
+	params["fields"] = StrType
+	params["sort"] = StrType
+	params["limit"] = IntType
+	params["cursor"] = StrType
+	return params
 }
 
-// DBS integer parameters
-var intParameters = []string{
-	"cdate",
-	"ldate",
-	"min_cdate",
-	"max_cdate",
-	"min_ldate",
-	"max_ldate",
-	"dataset_id",
-	"prep_id",
+// ApiParameters defines HTTP query parameters accepted by DBS APIs, any
+// other parameter is rejected
+var ApiParameters = map[string]Parameters{
+	"dataset": withPaging(Parameters{
+		"dataset": StrType,
+		"dry_run": BoolType,
+		"force":   BoolType,
+	}),
+	"file": withPaging(Parameters{
+		"logical_file_name": StrType,
+		"dataset":           StrType,
+		"is_file_valid":     FlagType,
+		"adler32":           StrType,
+		"md5":               StrType,
+		"sha256":            StrType,
+		"content_type":      StrType,
+		"file_size":         MixType,
+		"min_file_size":     IntType,
+		"max_file_size":     IntType,
+		"event_count":       MixType,
+		"min_event_count":   IntType,
+		"max_event_count":   IntType,
+	}),
+	"site":       withPaging(Parameters{"site": StrType, "dataset": StrType, "force": BoolType}),
+	"bucket":     withPaging(Parameters{"bucket": StrType, "dataset": StrType, "force": BoolType}),
+	"processing": withPaging(Parameters{"processing": StrType, "dataset": StrType, "force": BoolType}),
+	"parent":     withPaging(Parameters{"parent": StrType, "dataset": StrType, "force": BoolType}),
+	"lineage": Parameters{
+		"dataset":   StrType,
+		"direction": StrType,
+		"depth":     IntType,
+		"format":    StrType,
+	},
+	"datasetsummary": Parameters{"dataset": StrType},
 }
 
-// DBS mix type parameters
-var mixParameters = []string{"run_num"}
+// lexicon patterns of DBS API parameters
+var parameterPatterns = map[string]string{
+	"dataset":           "dataset",
+	"parent":            "dataset",
+	"logical_file_name": "logical_file_name",
+	"site":              "site",
+	"bucket":            "bucket",
+	"processing":        "processing",
+}
 
 // Lexicon represents single lexicon pattern structure
 type Lexicon struct {
@@ -72,30 +102,46 @@ type LexiconPattern struct {
 	Patterns []*regexp.Regexp
 }
 
-// LexiconPatterns represents CMS Lexicon patterns
+// LexiconPatterns represents OreCast lexicon patterns, they should be
+// accessed via lexiconPattern and replaced via SetLexiconPatterns since
+// lexicon can be reloaded at run-time
 var LexiconPatterns map[string]LexiconPattern
 
-// LoadPatterns loads CMS Lexion patterns from given file
+// LoadPatterns loads OreCast lexicon patterns from given file
 // the format of the file is a list of the following dicts:
 // [ {"name": <name>, "patterns": [list of patterns], "length": int},...]
 func LoadPatterns(fname string) (map[string]LexiconPattern, error) {
-	data, err := ioutil.ReadFile(fname)
+	data, err := os.ReadFile(fname)
 	if err != nil {
 		log.Printf("Unable to read, file '%s', error: %v\n", fname, err)
 		return nil, Error(err, ReaderErrorCode, "", "dbs.validator.LoadPatterns")
 	}
-	var records []Lexicon
-	err = json.Unmarshal(data, &records)
+	pmap, err := parsePatterns(data)
 	if err != nil {
 		log.Printf("Unable to parse, file '%s', error: %v\n", fname, err)
-		return nil, Error(err, UnmarshalErrorCode, "", "dbs.validator.LoadPatterns")
+		return nil, err
+	}
+	return pmap, nil
+}
+
+// helper function to parse and compile lexicon patterns
+func parsePatterns(data []byte) (map[string]LexiconPattern, error) {
+	var records []Lexicon
+	err := json.Unmarshal(data, &records)
+	if err != nil {
+		return nil, Error(err, UnmarshalErrorCode, "", "dbs.validator.parsePatterns")
 	}
 	// fetch and compile all patterns
 	pmap := make(map[string]LexiconPattern)
 	for _, rec := range records {
 		var patterns []*regexp.Regexp
 		for _, pat := range rec.Patterns {
-			patterns = append(patterns, regexp.MustCompile(pat))
+			re, err := regexp.Compile(pat)
+			if err != nil {
+				msg := fmt.Sprintf("invalid pattern of %s lexicon", rec.Name)
+				return nil, Error(err, PatternErrorCode, msg, "dbs.validator.parsePatterns")
+			}
+			patterns = append(patterns, re)
 		}
 		lex := LexiconPattern{Lexicon: rec, Patterns: patterns}
 		key := rec.Name
@@ -107,10 +153,91 @@ func LoadPatterns(fname string) (map[string]LexiconPattern, error) {
 	return pmap, nil
 }
 
+// ValidationError represents validation failure of single field of DBS record
+type ValidationError struct {
+	Field      string `json:"field"`             // name of the field
+	Value      any    `json:"value"`             // value of the field
+	Constraint string `json:"constraint"`        // failed constraint, e.g. required
+	Pattern    string `json:"pattern,omitempty"` // name of lexicon pattern
+}
+
+// ValidationErrors represents list of validation failures of DBS record
+type ValidationErrors []ValidationError
+
+// Error implements error interface for ValidationErrors
+func (e ValidationErrors) Error() string {
+	var msgs []string
+	for _, v := range e {
+		msg := fmt.Sprintf("field %s value '%v' fails %s constraint", v.Field, v.Value, v.Constraint)
+		if v.Pattern != "" {
+			msg = fmt.Sprintf("%s of %s lexicon pattern", msg, v.Pattern)
+		}
+		msgs = append(msgs, msg)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Add adds validation failure of given field
+func (e *ValidationErrors) Add(field string, value any, constraint string) {
+	*e = append(*e, ValidationError{Field: field, Value: value, Constraint: constraint})
+}
+
+// AddPattern adds validation failure of given field if its value does not
+// match lexicon pattern, empty values are checked by required constraint
+func (e *ValidationErrors) AddPattern(field, pattern, value string) {
+	if value == "" {
+		return
+	}
+	if err := CheckPattern(pattern, value); err != nil {
+		*e = append(*e, ValidationError{Field: field, Value: value, Constraint: "pattern", Pattern: pattern})
+	}
+}
+
+// AddDate adds validation failure of given field if it is not unix time
+func (e *ValidationErrors) AddDate(field string, value int64) {
+	if !unixTimePattern.MatchString(fmt.Sprintf("%d", value)) {
+		e.Add(field, value, "unix time")
+	}
+}
+
+// AddStruct adds validation failures of struct validator
+func (e *ValidationErrors) AddStruct(rec any) {
+	err := RecordValidator.Struct(rec)
+	if err == nil {
+		return
+	}
+	verrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		e.Add("", rec, err.Error())
+		return
+	}
+	for _, v := range verrs {
+		constraint := v.ActualTag()
+		if v.Param() != "" {
+			constraint = fmt.Sprintf("%s=%s", constraint, v.Param())
+		}
+		// JSON names of DBS record fields are lower case names of their attributes
+		e.Add(strings.ToLower(v.Field()), v.Value(), constraint)
+	}
+}
+
+// helper function to create validation error of DBS record, it returns nil
+// if there are no validation failures
+func validationError(errs ValidationErrors, function string) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return Error(errs, ValidateErrorCode, "", function)
+}
+
 // aux patterns
 var unixTimePattern = regexp.MustCompile(`^[1-9][0-9]{9}$`)
 var intPattern = regexp.MustCompile(`^\d+$`)
 var runRangePattern = regexp.MustCompile(`^\d+-\d+$`)
+var rangePattern = regexp.MustCompile(`^\s*\d+\s*-\s*\d+\s*$`)
+var adler32Pattern = regexp.MustCompile(`^[0-9a-fA-F]{1,8}$`)
+var md5Pattern = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+var sha256Pattern = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
 
 // ObjectPattern represents interface to check different objects
 type ObjectPattern interface {
@@ -205,159 +332,162 @@ func lfnList(v string) []string {
 }
 
 // helper function to validate string parameters
-//
-//gocyclo:ignore
-func strType(key string, val interface{}) error {
-	var v string
-	switch vvv := val.(type) {
-	case string:
-		v = vvv
-	default:
-		msg := fmt.Sprintf(
-			"invalid type of input parameter '%s' for value '%+v' type '%T'",
-			key, val, val)
-		return Error(InvalidParamErr, PatternErrorCode, msg, "dbs.validator.strType")
-	}
-	mapKeys := make(map[string]string)
-	mapKeys["dataset"] = "dataset"
-	mapKeys["block_name"] = "block_name"
-	mapKeys["logical_file_name"] = "logical_file_name"
-	mapKeys["create_by"] = "user"
-	mapKeys["last_modified_by"] = "user"
-	mapKeys["primary_ds_name"] = "primary_dataset"
-	mapKeys["processed_ds_name"] = "processed_dataset"
-	mapKeys["processing_version"] = "processing_version"
-	mapKeys["app_name"] = "application"
-	mapKeys["data_tier_name"] = "data_tier_name"
-	mapKeys["dataset"] = "dataset"
-	mapKeys["release_version"] = "cmssw_version"
-	var allowedWildCardKeys = []string{
-		"primary_ds_name",
-		"processed_ds_name",
-		"processing_version",
-		"app_name",
-		"data_tier_name",
-		"release_version",
+func strType(key string, val string) error {
+	// wildcard look-ups are not matched against lexicon patterns
+	if strings.Contains(val, "*") {
+		return nil
+	}
+	lkey, ok := parameterPatterns[key]
+	if !ok {
+		return nil
 	}
-
 	var patterns []*regexp.Regexp
 	var length int
-
-	for k, lkey := range mapKeys {
-		if key == k {
-			if utils.InList(k, allowedWildCardKeys) {
-				if v == "" && val == "*" { // when someone passed wildcard
-					return nil
-				}
-			}
-			if p, ok := LexiconPatterns[lkey]; ok {
-				patterns = p.Patterns
-				length = p.Lexicon.Length
-			}
-		}
-		if key == "logical_file_name" {
-			if strings.Contains(v, "[") {
-				if strings.Contains(v, "'") { // Python bad json, e.g. ['bla']
-					v = strings.Replace(v, "'", "\"", -1)
-				}
-				var records []string
-				err := json.Unmarshal([]byte(v), &records)
-				if err != nil {
-					return Error(err, UnmarshalErrorCode, "", "dbs.validator.strType")
-				}
-				for _, r := range records {
-					err := StrPattern{Patterns: patterns, Len: length}.Check(key, r)
-					if err != nil {
-						return Error(err, PatternErrorCode, "", "dbs.validator.strType")
-					}
-				}
-			}
-		}
-		if key == "block_name" {
-			if strings.Contains(v, "[") {
-				if strings.Contains(v, "'") { // Python bad json, e.g. ['bla']
-					v = strings.Replace(v, "'", "\"", -1)
-				}
-				// split input into pieces
-				input := strings.Replace(v, "[", "", -1)
-				input = strings.Replace(input, "]", "", -1)
-				for _, vvv := range strings.Split(input, ",") {
-					err := checkBlockHash(strings.Trim(vvv, " "))
-					if err != nil {
-						return err
-					}
-				}
-			} else {
-				err := checkBlockHash(v)
-				if err != nil {
-					return err
-				}
-			}
-		}
+	if p, ok := lexiconPattern(lkey); ok {
+		patterns = p.Patterns
+		length = p.Lexicon.Length
 	}
 	return StrPattern{Patterns: patterns, Len: length}.Check(key, val)
 }
 
-// helper function to check block hash
-func checkBlockHash(blk string) error {
-	arr := strings.Split(blk, "#")
-	if len(arr) != 2 {
-		msg := fmt.Sprintf("wrong parts in block name %s", blk)
-		return Error(ValidationErr, PatternErrorCode, msg, "dbs.validator.checkBlockHash")
+// helper function to validate int parameters
+func intType(key string, val string) error {
+	if !intPattern.MatchString(val) {
+		msg := fmt.Sprintf("invalid value '%s' of integer parameter '%s'", val, key)
+		return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.validator.intType")
 	}
-	if len(arr[1]) > 36 {
-		msg := fmt.Sprintf("wrong length of block hash %s", blk)
-		return Error(ValidationErr, PatternErrorCode, msg, "dbs.validator.checkBlockHash")
+	if _, err := strconv.ParseInt(val, 10, 64); err != nil {
+		return Error(err, ParametersErrorCode, "", "dbs.validator.intType")
 	}
 	return nil
 }
 
-// helper function to validate int parameters
-func intType(k string, v interface{}) error {
-	// to be implemented
+// helper function to validate mix parameters, i.e. either integer or range
+// of integers in min-max form
+func mixType(key string, val string) error {
+	if !rangePattern.MatchString(val) {
+		return intType(key, val)
+	}
+	minVal, maxVal, err := parseRange(val)
+	if err != nil {
+		return Error(err, ParametersErrorCode, "", "dbs.validator.mixType")
+	}
+	if minVal > maxVal {
+		msg := fmt.Sprintf("invalid range '%s' of parameter '%s', min value is greater than max value", val, key)
+		return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.validator.mixType")
+	}
 	return nil
 }
 
-// helper function to validate mix parameters
-func mixType(k string, v interface{}) error {
-	// to be implemented
+// helper function to validate bool parameters
+func boolType(key string, val string) error {
+	if _, err := strconv.ParseBool(val); err != nil {
+		msg := fmt.Sprintf("invalid value '%s' of boolean parameter '%s'", val, key)
+		return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.validator.boolType")
+	}
 	return nil
 }
 
-// Validate provides validation of all input parameters of HTTP request
-func Validate(r *http.Request) error {
-	if r.Method == "GET" {
-		for k, vvv := range r.URL.Query() {
-			// vvv here is []string{} type since all HTTP parameters are treated
-			// as list of strings
-			for _, v := range vvv {
-				if utils.InList(k, strParameters) {
-					if err := strType(k, v); err != nil {
-						return Error(err, ValidateErrorCode, "not str type", "dbs.Validate")
-					}
-				}
-				if utils.InList(k, intParameters) {
-					if err := intType(k, v); err != nil {
-						return Error(err, ValidateErrorCode, "not int type", "dbs.Validate")
-					}
+// helper function to validate flag parameters
+func flagType(key string, val string) error {
+	if val != "0" && val != "1" {
+		msg := fmt.Sprintf("invalid value '%s' of flag parameter '%s', should be 0 or 1", val, key)
+		return Error(InvalidParamErr, ParametersErrorCode, msg, "dbs.validator.flagType")
+	}
+	return nil
+}
+
+// helper function to parse range of integers in min-max form
+func parseRange(val string) (int64, int64, error) {
+	arr := strings.Split(val, "-")
+	if len(arr) != 2 {
+		return 0, 0, fmt.Errorf("invalid range '%s'", val)
+	}
+	minVal, err := strconv.ParseInt(strings.TrimSpace(arr[0]), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	maxVal, err := strconv.ParseInt(strings.TrimSpace(arr[1]), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return minVal, maxVal, nil
+}
+
+// Validate provides validation of HTTP query parameters of given DBS API
+// according to ApiParameters registry. All unknown or invalid parameters
+// are reported at once before any SQL statement is built.
+func Validate(api string, r *http.Request) error {
+	params, ok := ApiParameters[api]
+	if !ok {
+		msg := fmt.Sprintf("no parameters are defined for %s API", api)
+		return Error(NotImplementedApiErr, NotImplementedApiCode, msg, "dbs.Validate")
+	}
+	var errs ValidationErrors
+	query := r.URL.Query()
+	for k, vvv := range query {
+		ptype, ok := params[k]
+		if !ok {
+			errs.Add(k, strings.Join(vvv, ","), "unknown parameter")
+			continue
+		}
+		// vvv here is []string{} type since all HTTP parameters are treated
+		// as list of strings
+		for _, v := range vvv {
+			var err error
+			switch ptype {
+			case StrType:
+				err = strType(k, v)
+			case IntType:
+				err = intType(k, v)
+			case MixType:
+				err = mixType(k, v)
+			case BoolType:
+				err = boolType(k, v)
+			case FlagType:
+				err = flagType(k, v)
+			}
+			if err != nil {
+				constraint := ptype
+				if ptype == StrType {
+					constraint = "pattern"
 				}
-				if utils.InList(k, mixParameters) {
-					if err := mixType(k, v); err != nil {
-						return Error(err, ValidateErrorCode, "not mix type", "dbs.Validate")
-					}
+				errs = append(errs, ValidationError{
+					Field: k, Value: v, Constraint: constraint, Pattern: parameterPatterns[k]})
+				if utils.VERBOSE > 0 {
+					log.Println(err)
 				}
 			}
-			if utils.VERBOSE > 0 {
-				log.Printf("query parameter key=%s values=%+v\n", k, vvv)
-			}
+		}
+		if utils.VERBOSE > 0 {
+			log.Printf("query parameter key=%s values=%+v\n", k, vvv)
 		}
 	}
-	return nil
+	// range parameters should define valid range
+	for k := range params {
+		if !strings.HasPrefix(k, "min_") {
+			continue
+		}
+		name := strings.TrimPrefix(k, "min_")
+		minVal, err1 := strconv.ParseInt(query.Get(k), 10, 64)
+		maxVal, err2 := strconv.ParseInt(query.Get("max_"+name), 10, 64)
+		if err1 == nil && err2 == nil && minVal > maxVal {
+			errs.Add(k, minVal, fmt.Sprintf("ltefield=max_%s", name))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return Error(errs, ParametersErrorCode, "invalid query parameters", "dbs.Validate")
 }
 
 // CheckPattern is a generic functino to check given key value within Lexicon map
 func CheckPattern(key, value string) error {
-	if p, ok := LexiconPatterns[key]; ok {
+	if p, ok := lexiconPattern(key); ok {
+		if p.Lexicon.Length > 0 && len(value) > p.Lexicon.Length {
+			msg := fmt.Sprintf("length of key=%s exceed %d characters", key, p.Lexicon.Length)
+			return Error(InvalidParamErr, PatternErrorCode, msg, "dbs.CheckPattern")
+		}
 		for _, pat := range p.Patterns {
 			if matched := pat.MatchString(value); matched {
 				if utils.VERBOSE > 1 {
@@ -0,0 +1,191 @@
+package dbs
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// helper function to load lexicon of DBS static files, active lexicon is
+// restored when test is finished
+func loadTestLexicon(t *testing.T) {
+	t.Helper()
+	status := GetLexiconStatus()
+	patterns := LexiconPatterns
+	lexiconFile := LexiconFile
+	t.Cleanup(func() {
+		LexiconFile = lexiconFile
+		SetLexiconPatterns(patterns, status.Source)
+	})
+	LexiconFile = ""
+	if err := LoadLexicon(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// helper function to get validation failures of given error
+func validationErrors(t *testing.T, err error) ValidationErrors {
+	t.Helper()
+	var dbsError *DBSError
+	if !errors.As(err, &dbsError) {
+		t.Fatalf("expected DBS error, got %v", err)
+	}
+	if HttpStatus(err) != http.StatusUnprocessableEntity {
+		t.Errorf("wrong HTTP status %d of validation error %v", HttpStatus(err), err)
+	}
+	return dbsError.ValidationErrors
+}
+
+// TestValidationErrors tests collection of field-level validation failures
+func TestValidationErrors(t *testing.T) {
+	loadTestLexicon(t)
+	var errs ValidationErrors
+	errs.Add("create_by", "", "required")
+	errs.AddPattern("site", "site", "")         // empty values are checked by required constraint
+	errs.AddPattern("site", "site", "T1_CH")    // valid site
+	errs.AddPattern("dataset", "dataset", "/a") // invalid dataset
+	errs.AddDate("creation_date", 1700000000)   // valid unix time
+	errs.AddDate("last_modification_date", 123)
+	expect := ValidationErrors{
+		{Field: "create_by", Value: "", Constraint: "required"},
+		{Field: "dataset", Value: "/a", Constraint: "pattern", Pattern: "dataset"},
+		{Field: "last_modification_date", Value: int64(123), Constraint: "unix time"},
+	}
+	if len(errs) != len(expect) {
+		t.Fatalf("wrong validation errors %+v, expect %+v", errs, expect)
+	}
+	for i, e := range expect {
+		if errs[i] != e {
+			t.Errorf("wrong validation error %+v, expect %+v", errs[i], e)
+		}
+	}
+	msg := "field dataset value '/a' fails pattern constraint of dataset lexicon pattern"
+	if !strings.Contains(errs.Error(), msg) {
+		t.Errorf("wrong error message '%s'", errs.Error())
+	}
+	if err := validationError(nil, "test"); err != nil {
+		t.Errorf("expected no error without validation failures, got %v", err)
+	}
+}
+
+// TestAddStruct tests validation failures of struct validator
+func TestAddStruct(t *testing.T) {
+	initTestDB(t)
+	var errs ValidationErrors
+	errs.AddStruct(Datasets{DATASET: "/a/b/c", META_ID: "m1"})
+	fields := make(map[string]string)
+	for _, e := range errs {
+		fields[e.Field] = e.Constraint
+	}
+	for _, field := range []string{"site_id", "creation_date", "create_by"} {
+		if fields[field] != "required" {
+			t.Errorf("field %s fails '%s' constraint, expect required", field, fields[field])
+		}
+	}
+	if _, ok := fields["dataset"]; ok {
+		t.Errorf("valid dataset field is reported in %+v", errs)
+	}
+	verrs := validationErrors(t, DecodeValidatorError(Datasets{}, errors.New("invalid record")))
+	if len(verrs) == 0 {
+		t.Error("DecodeValidatorError does not report validation failures")
+	}
+}
+
+// TestDatasetValidate tests that all validation failures of dataset record
+// are reported
+func TestDatasetValidate(t *testing.T) {
+	loadTestLexicon(t)
+	rec := Datasets{
+		DATASET:                "/a",
+		META_ID:                "bad id",
+		CREATION_DATE:          123,
+		LAST_MODIFICATION_DATE: Date(),
+		LAST_MODIFIED_BY:       "test",
+	}
+	verrs := validationErrors(t, rec.Validate())
+	var fields []string
+	for _, e := range verrs {
+		fields = append(fields, e.Field)
+	}
+	expect := "dataset,meta_id,creation_date,create_by"
+	if strings.Join(fields, ",") != expect {
+		t.Errorf("wrong fields of validation errors %v, expect %s", fields, expect)
+	}
+
+	// validation failures are reported by DBS API along with outer errors
+	initTestDB(t)
+	api, _ := testApi(nil, `{"dataset": "/a", "buckets": ["b1"], "site": "s1", "processing": "p1", "meta_id": "bad id"}`)
+	verrs = validationErrors(t, api.InsertDataset())
+	if len(verrs) != 2 || verrs[0].Field != "dataset" || verrs[1].Field != "meta_id" {
+		t.Errorf("wrong validation errors of InsertDataset %+v", verrs)
+	}
+}
+
+// TestValidate tests validation of HTTP query parameters of DBS APIs
+func TestValidate(t *testing.T) {
+	loadTestLexicon(t)
+	tests := []struct {
+		api    string
+		query  string
+		fields string // fields of validation errors, empty if query is valid
+	}{
+		{"dataset", "dataset=/a/b/c&limit=10&sort=dataset&fields=dataset", ""},
+		{"dataset", "dataset=/a/*", ""}, // wildcards are not matched against lexicon
+		{"dataset", "dataset=/a/b/c&dry_run=true&force=1", ""},
+		{"dataset", "dataset=a&bogus=1", "dataset,bogus"},
+		{"dataset", "limit=-1", "limit"},
+		{"dataset", "force=maybe", "force"},
+		{"file", "file_size=10-20&event_count=5&is_file_valid=1", ""},
+		{"file", "is_file_valid=7", "is_file_valid"},
+		{"file", "is_file_valid=true", "is_file_valid"},
+		{"file", "file_size=20-10", "file_size"},
+		{"file", "event_count=a-b", "event_count"},
+		{"file", "min_file_size=1&max_file_size=2", ""},
+		{"file", "min_file_size=3&max_file_size=2", "min_file_size"},
+		{"file", "min_file_size=x", "min_file_size"},
+		{"file", "logical_file_name=/a/f1,/a/f2", ""},
+		{"file", "logical_file_name=/a/f1,f2", "logical_file_name"},
+		{"site", "site=T1_CH&dataset=/a/b/c", ""},
+		{"site", "bucket=b1", "bucket"},
+		{"bucket", "bucket=Bad_Bucket", "bucket"},
+		{"lineage", "dataset=/a/b/c&depth=2&direction=parents", ""},
+		{"lineage", "depth=two", "depth"},
+		{"lineage", "limit=1", "limit"}, // lineage does not support paging
+		{"datasetsummary", "dataset=/a/b/c&sort=dataset", "sort"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/?"+tt.query, nil)
+		err := Validate(tt.api, r)
+		if tt.fields == "" {
+			if err != nil {
+				t.Errorf("%s API query %s: unexpected error %v", tt.api, tt.query, err)
+			}
+			continue
+		}
+		if HttpStatus(err) != http.StatusBadRequest {
+			t.Errorf("%s API query %s: wrong HTTP status %d of error %v", tt.api, tt.query, HttpStatus(err), err)
+			continue
+		}
+		var dbsError *DBSError
+		errors.As(err, &dbsError)
+		fields := make(map[string]bool)
+		for _, e := range dbsError.ValidationErrors {
+			fields[e.Field] = true
+		}
+		for _, field := range strings.Split(tt.fields, ",") {
+			if !fields[field] {
+				t.Errorf("%s API query %s: field %s is not reported in %+v",
+					tt.api, tt.query, field, dbsError.ValidationErrors)
+			}
+		}
+		if len(fields) != len(strings.Split(tt.fields, ",")) {
+			t.Errorf("%s API query %s: wrong validation errors %+v", tt.api, tt.query, dbsError.ValidationErrors)
+		}
+	}
+	r := httptest.NewRequest("GET", "/", nil)
+	if err := Validate("bogus", r); HttpStatus(err) != http.StatusNotImplemented {
+		t.Errorf("wrong HTTP status %d of unknown API error %v", HttpStatus(err), err)
+	}
+}
@@ -3,13 +3,14 @@ package main
 import (
 	"bytes"
 	"compress/gzip"
-	"errors"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/OreCast/DataBookkeeping/dbs"
 	"github.com/OreCast/DataBookkeeping/utils"
@@ -30,8 +31,66 @@ func DatasetHandler(c *gin.Context) {
 	ApiHandler(c, "dataset")
 }
 
+// SiteHandler provides access to GET /sites and /site/:name end-point
+func SiteHandler(c *gin.Context) {
+	ApiHandler(c, "site")
+}
+
+// BucketHandler provides access to GET /buckets and /bucket/:name end-point
+func BucketHandler(c *gin.Context) {
+	ApiHandler(c, "bucket")
+}
+
+// ProcessingHandler provides access to GET /processing and /processing/:name end-point
+func ProcessingHandler(c *gin.Context) {
+	ApiHandler(c, "processing")
+}
+
+// ParentHandler provides access to GET /parents and /parent/:name end-point
+func ParentHandler(c *gin.Context) {
+	ApiHandler(c, "parent")
+}
+
+// LineageHandler provides access to GET /lineage/:name end-point
+func LineageHandler(c *gin.Context) {
+	ApiHandler(c, "lineage")
+}
+
+// DatasetSummaryHandler provides access to GET /datasetsummary and /datasetsummary/:name end-point
+func DatasetSummaryHandler(c *gin.Context) {
+	ApiHandler(c, "datasetsummary")
+}
+
+// HealthzHandler provides access to GET /healthz end-point used by liveness probes
+func HealthzHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// ReadyzHandler provides access to GET /readyz end-point used by readiness
+// probes, it reports DB status and fails if DB is not ready to serve requests
+func ReadyzHandler(c *gin.Context) {
+	status := dbs.CheckReadiness()
+	if !status.Ready {
+		log.Printf("server is not ready: %v", status.Errors)
+		c.JSON(http.StatusServiceUnavailable, status)
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// LexiconHandler provides access to GET /lexicon end-point which reports
+// active lexicon patterns used to validate DBS records and parameters
+func LexiconHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, dbs.GetLexiconStatus())
+}
+
 // ApiHandler represents generic API handler for GET/POST/PUT/DELETE requests of a specific API
 func ApiHandler(c *gin.Context, api string) {
+	// DB queries of the API are canceled when client disconnects or API
+	// does not complete within its timeout
+	ctx, cancel := context.WithTimeout(c.Request.Context(), dbs.ApiTimeoutOf(api))
+	defer cancel()
+	c.Request = c.Request.WithContext(ctx)
 	r := c.Request
 	if r.Method == "POST" {
 		DBSPostHandler(c, api)
@@ -59,35 +118,49 @@ func getApi(c *gin.Context, a string) (*dbs.API, error) {
 		w.Header().Add("Content-Type", "application/ndjson")
 	}
 
+	// reject unknown or invalid query parameters before API is called
+	if err := dbs.Validate(a, r); err != nil {
+		return nil, err
+	}
+
 	var api *dbs.API
 	params := make(dbs.Record)
-	if r.Method == "GET" {
-		// for example /file?dataset=/x/y/z we'll parse URL query
-		// r.URL.Query() returns map[string][]string
-		for k, values := range r.URL.Query() {
-			var vals []string
-			for _, v := range values {
-				vals = append(vals, v)
-			}
-			params[k] = vals
+	// for example /file?dataset=/x/y/z we'll parse URL query
+	// r.URL.Query() returns map[string][]string
+	for k, values := range r.URL.Query() {
+		var vals []string
+		for _, v := range values {
+			vals = append(vals, v)
 		}
+		params[k] = vals
 	}
-	if r.Method == "GET" || r.Method == "DELETE" {
+	if r.Method == "GET" {
+		api = &dbs.API{
+			Writer:      w,
+			Context:     r.Context(),
+			Params:      params,
+			Separator:   sep,
+			Api:         a,
+			ContentType: r.Header.Get("Content-Type"),
+		}
+	} else if r.Method == "DELETE" { // DELETE requests may contain optional payload
 		api = &dbs.API{
+			Reader:      r.Body,
 			Writer:      w,
+			Context:     r.Context(),
 			Params:      params,
 			Separator:   sep,
+			CreateBy:    createBy(r),
 			Api:         a,
 			ContentType: r.Header.Get("Content-Type"),
 		}
 	} else { // all other HTTP requests POST/PUT may contain payload
 
 		headerContentType := r.Header.Get("Content-Type")
-		if headerContentType != "application/json" {
+		if headerContentType != "application/json" &&
+			!(r.Method == "PUT" && headerContentType == "application/merge-patch+json") {
 			msg := fmt.Sprintf("unsupported Content-Type: '%s'", headerContentType)
-			e := dbs.Error(dbs.ContentTypeErr, dbs.ContentTypeErrorCode, msg, "web.DBSPostHandler")
-			responseMsg(w, r, e, http.StatusUnsupportedMediaType)
-			return nil, errors.New(msg)
+			return nil, dbs.Error(dbs.ContentTypeErr, dbs.ContentTypeErrorCode, msg, "web.getApi")
 		}
 		defer r.Body.Close()
 		//         var params dbs.Record
@@ -104,25 +177,22 @@ func getApi(c *gin.Context, a string) (*dbs.API, error) {
 			if err != nil {
 				msg := "unable to get gzip reader"
 				log.Println(msg, err)
-				e := dbs.Error(err, dbs.ReaderErrorCode, msg, "web.DBSPostHandler")
-				responseMsg(w, r, e, http.StatusInternalServerError)
-				return nil, errors.New(msg)
+				return nil, dbs.Error(err, dbs.ReaderErrorCode, msg, "web.getApi")
 			}
-			body = utils.GzipReader{reader, r.Body}
+			body = utils.GzipReader{Reader: reader, Closer: r.Body}
 		} else {
 			data, err := io.ReadAll(r.Body)
 			if err != nil {
 				msg := "unable to get io reader"
 				log.Println(msg, err)
-				e := dbs.Error(err, dbs.ReaderErrorCode, msg, "web.DBSPostHandler")
-				responseMsg(w, r, e, http.StatusInternalServerError)
-				return nil, errors.New(msg)
+				return nil, dbs.Error(err, dbs.ReaderErrorCode, msg, "web.getApi")
 			}
 			body = ioutil.NopCloser(bytes.NewBuffer(data))
 		}
 		api = &dbs.API{
 			Reader:      body,
 			Writer:      w,
+			Context:     r.Context(),
 			Params:      params,
 			Separator:   sep,
 			CreateBy:    cby,
@@ -144,10 +214,16 @@ func getApi(c *gin.Context, a string) (*dbs.API, error) {
 	// get name out of it
 	var rest NameRequest
 	if err := c.ShouldBindUri(&rest); err == nil {
-		if a == "dataset" && rest.Name != "" {
+		if (a == "dataset" || a == "lineage" || a == "datasetsummary") && rest.Name != "" {
 			api.Params["dataset"] = rest.Name
 		} else if a == "file" && rest.Name != "" {
 			api.Params["logical_file_name"] = rest.Name
+		} else if a == "parent" && rest.Name != "" {
+			// parents are dataset names and therefore keep leading slash
+			api.Params["parent"] = rest.Name
+		} else if rest.Name != "" && rest.Name != "/" {
+			// site, bucket and processing names do not have leading slash
+			api.Params[a] = strings.TrimPrefix(rest.Name, "/")
 		}
 	}
 
@@ -164,6 +240,10 @@ func DBSGetHandler(c *gin.Context, a string) {
 	r := c.Request
 	w := c.Writer
 	api, err := getApi(c, a)
+	if err != nil {
+		responseMsg(w, r, err, dbs.HttpStatus(err))
+		return
+	}
 	if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
 		w.Header().Set("Content-Encoding", "gzip")
 		api.ContentType = "gzip"
@@ -171,18 +251,30 @@ func DBSGetHandler(c *gin.Context, a string) {
 		defer gw.Close()
 		api.Writer = utils.GzipWriter{GzipWriter: gw, Writer: w}
 	}
-	if err != nil {
-		responseMsg(w, r, err, http.StatusBadRequest)
-	}
+	start := time.Now()
 	if a == "dataset" {
 		err = api.GetDataset()
 	} else if a == "file" {
 		err = api.GetFile()
+	} else if a == "site" {
+		err = api.GetSite()
+	} else if a == "bucket" {
+		err = api.GetBucket()
+	} else if a == "processing" {
+		err = api.GetProcessing()
+	} else if a == "parent" {
+		err = api.GetParent()
+	} else if a == "lineage" {
+		err = api.GetLineage()
+	} else if a == "datasetsummary" {
+		err = api.GetDatasetSummary()
 	} else {
 		err = dbs.NotImplementedApiErr
 	}
+	err = dbs.ContextError(r.Context(), a, err)
+	_metrics.ObserveApi(a, r.Method, time.Since(start), err)
 	if err != nil {
-		responseMsg(w, r, err, http.StatusBadRequest)
+		responseMsg(w, r, err, dbs.HttpStatus(err))
 		return
 	}
 }
@@ -197,17 +289,29 @@ func DBSPostHandler(c *gin.Context, a string) {
 	w := c.Writer
 	api, err := getApi(c, a)
 	if err != nil {
-		responseMsg(w, r, err, http.StatusBadRequest)
+		responseMsg(w, r, err, dbs.HttpStatus(err))
+		return
 	}
+	start := time.Now()
 	if a == "dataset" {
 		err = api.InsertDataset()
 	} else if a == "file" {
 		err = api.InsertFile()
+	} else if a == "site" {
+		err = api.InsertSite()
+	} else if a == "bucket" {
+		err = api.InsertBucket()
+	} else if a == "processing" {
+		err = api.InsertProcessing()
+	} else if a == "parent" {
+		err = api.InsertParent()
 	} else {
 		err = dbs.NotImplementedApiErr
 	}
+	err = dbs.ContextError(r.Context(), a, err)
+	_metrics.ObserveApi(a, r.Method, time.Since(start), err)
 	if err != nil {
-		responseMsg(w, r, err, http.StatusBadRequest)
+		responseMsg(w, r, err, dbs.HttpStatus(err))
 		return
 	}
 }
@@ -220,17 +324,29 @@ func DBSPutHandler(c *gin.Context, a string) {
 	w := c.Writer
 	api, err := getApi(c, a)
 	if err != nil {
-		responseMsg(w, r, err, http.StatusBadRequest)
+		responseMsg(w, r, err, dbs.HttpStatus(err))
+		return
 	}
+	start := time.Now()
 	if a == "dataset" {
 		err = api.UpdateDataset()
 	} else if a == "file" {
 		err = api.UpdateFile()
+	} else if a == "site" {
+		err = api.UpdateSite()
+	} else if a == "bucket" {
+		err = api.UpdateBucket()
+	} else if a == "processing" {
+		err = api.UpdateProcessing()
+	} else if a == "parent" {
+		err = api.UpdateParent()
 	} else {
 		err = dbs.NotImplementedApiErr
 	}
+	err = dbs.ContextError(r.Context(), a, err)
+	_metrics.ObserveApi(a, r.Method, time.Since(start), err)
 	if err != nil {
-		responseMsg(w, r, err, http.StatusBadRequest)
+		responseMsg(w, r, err, dbs.HttpStatus(err))
 		return
 	}
 }
@@ -243,17 +359,29 @@ func DBSDeleteHandler(c *gin.Context, a string) {
 	w := c.Writer
 	api, err := getApi(c, a)
 	if err != nil {
-		responseMsg(w, r, err, http.StatusBadRequest)
+		responseMsg(w, r, err, dbs.HttpStatus(err))
+		return
 	}
+	start := time.Now()
 	if a == "dataset" {
 		err = api.DeleteDataset()
 	} else if a == "file" {
 		err = api.DeleteFile()
+	} else if a == "site" {
+		err = api.DeleteSite()
+	} else if a == "bucket" {
+		err = api.DeleteBucket()
+	} else if a == "processing" {
+		err = api.DeleteProcessing()
+	} else if a == "parent" {
+		err = api.DeleteParent()
 	} else {
 		err = dbs.NotImplementedApiErr
 	}
+	err = dbs.ContextError(r.Context(), a, err)
+	_metrics.ObserveApi(a, r.Method, time.Since(start), err)
 	if err != nil {
-		responseMsg(w, r, err, http.StatusBadRequest)
+		responseMsg(w, r, err, dbs.HttpStatus(err))
 		return
 	}
 }
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/OreCast/DataBookkeeping/dbs"
+)
+
+// TestQueryValidation tests that unknown or invalid query parameters are
+// rejected before DBS API is called
+func TestQueryValidation(t *testing.T) {
+	r := initTestServer(t)
+	headers := map[string]string{"Accept": "application/problem+json"}
+	tests := []struct {
+		path  string
+		field string
+	}{
+		{"/datasets?bogus=1", "bogus"},
+		{"/datasets?dataset=abc", "dataset"},
+		{"/files?is_file_valid=7", "is_file_valid"},
+		{"/files?file_size=20-10", "file_size"},
+		{"/files?min_event_count=5&max_event_count=1", "min_event_count"},
+		{"/sites?limit=ten", "limit"},
+		{"/lineage/a/b/c?depth=x", "depth"},
+	}
+	for _, tt := range tests {
+		w := serveRequest(r, "GET", tt.path, "", headers)
+		checkStatus(t, w, http.StatusBadRequest)
+		var problem Problem
+		if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+			t.Fatal(err)
+		}
+		if problem.Code != dbs.ParametersErrorCode || len(problem.ValidationErrors) != 1 ||
+			problem.ValidationErrors[0].Field != tt.field {
+			t.Errorf("%s: wrong problem details %+v", tt.path, problem)
+		}
+	}
+	// valid query parameters are passed to DBS API
+	w := serveRequest(r, "GET", "/files?file_size=10-20&limit=5", "", nil)
+	checkStatus(t, w, http.StatusOK)
+}
+
+// TestLexiconHandler tests that active lexicon patterns are exposed
+func TestLexiconHandler(t *testing.T) {
+	r := initTestServer(t)
+	w := serveRequest(r, "GET", "/lexicon", "", nil)
+	checkStatus(t, w, http.StatusOK)
+	var status dbs.LexiconStatus
+	if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]bool)
+	for _, p := range status.Patterns {
+		if len(p.Patterns) == 0 {
+			t.Errorf("lexicon %s has no patterns", p.Name)
+		}
+		names[p.Name] = true
+	}
+	for _, name := range dbs.RequiredLexicons {
+		if !names[name] {
+			t.Errorf("lexicon %s is not exposed in %+v", name, status)
+		}
+	}
+	if status.Source == "" || status.Loaded.IsZero() {
+		t.Errorf("wrong lexicon status %+v", status)
+	}
+}
@@ -45,7 +45,46 @@ type ServerError struct {
 	Message   string    `json:"message"`   // for compatibility with Python server
 }
 
-// responseMsg helper function to provide response to end-user
+// Problem represents RFC 7807 problem details of HTTP error, see
+// https://www.rfc-editor.org/rfc/rfc7807
+type Problem struct {
+	Type     string `json:"type"`               // URI of problem type
+	Title    string `json:"title"`              // short summary of problem type
+	Status   int    `json:"status"`             // HTTP status code
+	Detail   string `json:"detail"`             // explanation of the problem
+	Instance string `json:"instance"`           // URI of the request
+	Code     int    `json:"code,omitempty"`     // DBS error code
+	Function string `json:"function,omitempty"` // DBS function
+
+	// field-level failures of DBS record validation
+	ValidationErrors dbs.ValidationErrors `json:"validation_errors,omitempty"`
+}
+
+// helper function to provide RFC 7807 problem details of given error
+func problemDetails(path string, err error, code int) Problem {
+	rec := Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(code),
+		Status:   code,
+		Detail:   err.Error(),
+		Instance: path,
+	}
+	// report DBS error which defines HTTP status of the problem
+	if _, dbsError := dbs.ErrorStatus(err); dbsError != nil {
+		rec.Code = dbsError.Code
+		rec.Function = dbsError.Function
+		rec.ValidationErrors = dbsError.ValidationErrors
+		rec.Detail = dbsError.Explain()
+		if dbsError.Message != "" {
+			rec.Detail = fmt.Sprintf("%s: %s", rec.Detail, dbsError.Message)
+		}
+	}
+	return rec
+}
+
+// responseMsg helper function to provide response to end-user. The error is
+// provided either as list of ServerError records or, if client accepts
+// application/problem+json, as RFC 7807 problem details.
 func responseMsg(w http.ResponseWriter, r *http.Request, err error, code int) int64 {
 	path := r.RequestURI
 	uri, e := url.QueryUnescape(r.RequestURI)
@@ -76,13 +115,20 @@ func responseMsg(w http.ResponseWriter, r *http.Request, err error, code int) in
 	} else {
 		log.Printf(err.Error())
 	}
+	if strings.Contains(r.Header.Get("Accept"), "application/problem+json") {
+		data, _ := json.Marshal(problemDetails(path, err, code))
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(code)
+		w.Write(data)
+		return int64(len(data))
+	}
 	// if we want to use JSON record output we'll use
 	//     data, _ := json.Marshal(rec)
 	// otherwise we'll use list of JSON records
 	var out []ServerError
 	out = append(out, rec)
 	data, _ := json.Marshal(out)
-	w.Header().Add("Content-Type", "application/json")
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
 	w.Write(data)
 	return int64(len(data))
@@ -130,3 +176,25 @@ func createBy(r *http.Request) string {
 	}
 	return cby
 }
+
+// helper function to parse timeouts of specific APIs provided as comma
+// separated list of api=duration pairs, e.g. file=10m,lineage=1m
+func parseApiTimeouts(val string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, pair := range strings.Split(val, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		arr := strings.SplitN(pair, "=", 2)
+		if len(arr) != 2 {
+			return nil, fmt.Errorf("invalid API timeout '%s', should be api=duration", pair)
+		}
+		tout, err := time.ParseDuration(arr[1])
+		if err != nil || tout <= 0 {
+			return nil, fmt.Errorf("invalid duration of API timeout '%s'", pair)
+		}
+		timeouts[strings.TrimSpace(arr[0])] = tout
+	}
+	return timeouts, nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/OreCast/DataBookkeeping/dbs"
+)
+
+// TestProblemDetails tests RFC 7807 problem details of failed requests
+func TestProblemDetails(t *testing.T) {
+	r := initTestServer(t)
+	headers := map[string]string{"Accept": "application/problem+json"}
+
+	w := serveRequest(r, "GET", "/datasetsummary/x/y/z", "", headers)
+	checkStatus(t, w, http.StatusNotFound)
+	if ctype := w.Header().Get("Content-Type"); ctype != "application/problem+json" {
+		t.Errorf("wrong content type of problem details %s", ctype)
+	}
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	if problem.Type != "about:blank" || problem.Title != "Not Found" || problem.Status != http.StatusNotFound ||
+		problem.Instance != "/datasetsummary/x/y/z" || problem.Code != dbs.DatasetDoesNotExist ||
+		problem.Function != "dbs.datasets.getDataset" || problem.Detail == "" {
+		t.Errorf("wrong problem details %+v", problem)
+	}
+
+	w = serveRequest(r, "GET", "/datasets?sort=bogus", "", headers)
+	checkStatus(t, w, http.StatusBadRequest)
+	problem = Problem{}
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	if problem.Status != http.StatusBadRequest || problem.Code != dbs.ParametersErrorCode {
+		t.Errorf("wrong problem details %+v", problem)
+	}
+
+	// clients which do not accept problem details get list of DBS errors
+	w = serveRequest(r, "GET", "/datasetsummary/x/y/z", "", nil)
+	checkStatus(t, w, http.StatusNotFound)
+	var errs []map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &errs); err != nil || len(errs) != 1 {
+		t.Fatalf("wrong list of DBS errors %s, error %v", w.Body.String(), err)
+	}
+	if errs[0]["exception"] != float64(http.StatusNotFound) || w.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("wrong DBS error %v", errs[0])
+	}
+}
+
+// TestValidationProblem tests that field-level validation failures are
+// reported in problem details
+func TestValidationProblem(t *testing.T) {
+	r := initTestServer(t)
+	headers := authHeaders(t)
+	headers["Accept"] = "application/problem+json"
+	payload := `{"dataset": "/a", "buckets": ["b1"], "site": "s1", "processing": "p1", "meta_id": "bad id"}`
+	w := serveRequest(r, "POST", "/dataset", payload, headers)
+	checkStatus(t, w, http.StatusUnprocessableEntity)
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	if problem.Code != dbs.ValidateErrorCode || len(problem.ValidationErrors) != 2 {
+		t.Fatalf("wrong problem details %+v", problem)
+	}
+	for i, field := range []string{"dataset", "meta_id"} {
+		verr := problem.ValidationErrors[i]
+		if verr.Field != field || verr.Constraint != "pattern" || verr.Pattern != field {
+			t.Errorf("wrong validation error %+v", verr)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/OreCast/DataBookkeeping/dbs"
+	"github.com/OreCast/DataBookkeeping/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// ServerInfo represents information about server build, its backend and configuration
+type ServerInfo struct {
+	GitVersion      string   `json:"git_version"`      // git version of the server
+	GoVersion       string   `json:"go_version"`       // Go version used to build the server
+	StartTime       string   `json:"start_time"`       // start time of the server
+	Uptime          float64  `json:"uptime"`           // uptime of the server in seconds
+	DBType          string   `json:"db_type"`          // database driver, e.g. sqlite3
+	DBBackend       string   `json:"db_backend"`       // database backend, e.g. sqlite or postgres
+	DBOwner         string   `json:"db_owner"`         // database owner (schema)
+	SQLTemplates    int      `json:"sql_templates"`    // number of loaded SQL templates
+	LexiconLoaded   bool     `json:"lexicon_loaded"`   // lexicon patterns are loaded
+	LexiconPatterns int      `json:"lexicon_patterns"` // number of loaded lexicon patterns
+	StaticDir       string   `json:"static_dir"`       // static files override area
+	Apis            []string `json:"apis"`             // enabled APIs as HTTP method and path
+}
+
+// list of server routes, it is set by setupRouter
+var _routes gin.RoutesInfo
+
+// serverInfo provides information about the server, it does not include
+// database URI since it may contain credentials
+func serverInfo() ServerInfo {
+	var apis []string
+	for _, route := range _routes {
+		apis = append(apis, fmt.Sprintf("%s %s", route.Method, route.Path))
+	}
+	sort.Strings(apis)
+	lexicon := dbs.GetLexiconStatus()
+	return ServerInfo{
+		GitVersion:      gitVersion,
+		GoVersion:       runtime.Version(),
+		StartTime:       _startTime.Format(time.RFC3339),
+		Uptime:          time.Since(_startTime).Seconds(),
+		DBType:          dbs.DBTYPE,
+		DBBackend:       dbs.Backend(),
+		DBOwner:         dbs.DBOWNER,
+		SQLTemplates:    len(dbs.DBSQL),
+		LexiconLoaded:   len(lexicon.Patterns) > 0,
+		LexiconPatterns: len(lexicon.Patterns),
+		StaticDir:       utils.STATICDIR,
+		Apis:            apis,
+	}
+}
+
+// InfoHandler provides access to GET /info end-point
+func InfoHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, serverInfo())
+}
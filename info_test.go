@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"testing"
+
+	"github.com/OreCast/DataBookkeeping/utils"
+)
+
+// TestInfo tests server info end-point
+func TestInfo(t *testing.T) {
+	r := initTestServer(t)
+	w := serveRequest(r, "GET", "/info", "", nil)
+	checkStatus(t, w, http.StatusOK)
+	var info ServerInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &info); err != nil {
+		t.Fatal(err)
+	}
+	if info.GoVersion != runtime.Version() || info.GitVersion != gitVersion || info.Uptime <= 0 {
+		t.Errorf("wrong server version info %+v", info)
+	}
+	if info.DBType != "sqlite3" || info.DBBackend != "sqlite" || info.DBOwner != "sqlite" {
+		t.Errorf("wrong database info %+v", info)
+	}
+	if info.SQLTemplates == 0 || !info.LexiconLoaded || info.LexiconPatterns == 0 {
+		t.Errorf("wrong templates or lexicon info %+v", info)
+	}
+	if !utils.InList("GET /info", info.Apis) || !utils.InList("DELETE /file", info.Apis) {
+		t.Errorf("wrong server APIs %v", info.Apis)
+	}
+	// database URI is never reported
+	var rec map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &rec); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"db_uri", "dburi", "db_file"} {
+		if _, ok := rec[key]; ok {
+			t.Errorf("server info reports %s", key)
+		}
+	}
+}
@@ -15,16 +15,23 @@ import (
 	_ "expvar"         // to be used for monitoring, see https://github.com/divan/expvarmon
 	_ "net/http/pprof" // profiler, see https://golang.org/pkg/net/http/pprof/
 
+	"github.com/OreCast/DataBookkeeping/dbs"
 	oreConfig "github.com/OreCast/common/config"
 )
 
 // orecast configuration
 var _oreConfig *oreConfig.OreCastConfig
 
+// git version of the server, it is set at build time
+var gitVersion = "{{VERSION}}"
+
+// start time of the server
+var _startTime = time.Now()
+
 func info() string {
 	goVersion := runtime.Version()
 	tstamp := time.Now()
-	return fmt.Sprintf("git={{VERSION}} go=%s date=%s", goVersion, tstamp)
+	return fmt.Sprintf("git=%s go=%s date=%s", gitVersion, goVersion, tstamp)
 }
 
 func main() {
@@ -32,15 +39,41 @@ func main() {
 	flag.BoolVar(&version, "version", false, "Show version")
 	var config string
 	flag.StringVar(&config, "config", "", "server config JSON file")
+	var migrate string
+	flag.StringVar(&migrate, "migrate", "", "migrate database schema to given version (version number or latest) and exit")
+	var initDB bool
+	flag.BoolVar(&initDB, "init-db", false, "create database schema along with reference data in empty database and exit")
+	flag.DurationVar(&_timeouts.Read, "read-timeout", _timeouts.Read, "HTTP server read timeout")
+	flag.DurationVar(&_timeouts.Write, "write-timeout", _timeouts.Write, "HTTP server write timeout")
+	flag.DurationVar(&_timeouts.Idle, "idle-timeout", _timeouts.Idle, "HTTP server idle timeout of keep-alive connections")
+	flag.DurationVar(&_timeouts.Shutdown, "shutdown-timeout", _timeouts.Shutdown, "maximum time to drain in-flight requests on shutdown")
+	flag.DurationVar(&dbs.ApiTimeout, "api-timeout", dbs.ApiTimeout, "maximum duration of DBS API call")
+	flag.StringVar(&dbs.LexiconFile, "lexicon", "", "OreCast lexicon JSON file, by default lexicon.json of static files is used")
+	flag.DurationVar(&_lexiconInterval, "lexicon-interval", _lexiconInterval, "interval of checks of lexicon file changes, 0 disables reload on file change")
+	var apiTimeouts string
+	flag.StringVar(&apiTimeouts, "api-timeouts", "", "maximum durations of specific DBS APIs, e.g. file=10m,lineage=1m")
 	flag.Parse()
 	if version {
 		fmt.Println("server version:", info())
 		return
 	}
+	timeouts, err := parseApiTimeouts(apiTimeouts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	dbs.ApiTimeouts = timeouts
 	oConfig, err := oreConfig.ParseConfig(config)
 	if err != nil {
 		log.Fatal("ERROR", err)
 	}
 	_oreConfig = &oConfig
+	if initDB {
+		InitDB()
+		return
+	}
+	if migrate != "" {
+		Migrate(migrate)
+		return
+	}
 	Server()
 }
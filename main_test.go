@@ -0,0 +1,86 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OreCast/DataBookkeeping/dbs"
+	"github.com/OreCast/DataBookkeeping/utils"
+	oreConfig "github.com/OreCast/common/config"
+	"github.com/gin-gonic/gin"
+	validator "github.com/go-playground/validator/v10"
+	jwt "github.com/golang-jwt/jwt/v4"
+)
+
+// helper function to set up server router along with DBS test database
+// in temporary area
+func initTestServer(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	_oreConfig = &oreConfig.OreCastConfig{}
+	_oreConfig.Authz.ClientId = "test"
+	utils.STATICDIR = ""
+	dbs.RecordValidator = validator.New()
+	dbfile := filepath.Join(t.TempDir(), "dbs-test.db")
+	db, err := sql.Open("sqlite3", fmt.Sprintf("%s?_busy_timeout=30000&_foreign_keys=1", dbfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	dbs.DB = db
+	dbs.DBTYPE = "sqlite3"
+	dbs.DBOWNER = "sqlite"
+	dbs.DBSQL = dbs.LoadSQL("sqlite")
+	if err := dbs.InitDB(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dbs.LoadLexicon(); err != nil {
+		t.Fatal(err)
+	}
+	return setupRouter()
+}
+
+// helper function to serve HTTP request with given method, path and body
+func serveRequest(r *gin.Engine, method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	for key, val := range headers {
+		req.Header.Set(key, val)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// helper function to create authorization headers with access token signed
+// by client id of test server
+func authHeaders(t *testing.T) map[string]string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: "test"})
+	accessToken, err := token.SignedString([]byte(_oreConfig.Authz.ClientId))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]string{
+		"Authorization": "Bearer " + accessToken,
+		"Content-Type":  "application/json",
+	}
+}
+
+// helper function to check HTTP status code of the response
+func checkStatus(t *testing.T, w *httptest.ResponseRecorder, code int) {
+	t.Helper()
+	if w.Code != code {
+		t.Errorf("expected HTTP status %d (%s), got %d: %s",
+			code, http.StatusText(code), w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OreCast/DataBookkeeping/dbs"
+	"github.com/OreCast/DataBookkeeping/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsBuckets defines upper bounds (in seconds) of latency histograms
+var MetricsBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Histogram represents prometheus histogram of observed values
+type Histogram struct {
+	Counts []uint64 // number of observations per bucket
+	Count  uint64   // total number of observations
+	Sum    float64  // sum of observed values
+}
+
+// Observe adds given value to the histogram
+func (h *Histogram) Observe(val float64) {
+	if h.Counts == nil {
+		h.Counts = make([]uint64, len(MetricsBuckets))
+	}
+	for i, bound := range MetricsBuckets {
+		if val <= bound {
+			h.Counts[i]++
+		}
+	}
+	h.Count++
+	h.Sum += val
+}
+
+// Metrics represents server metrics collected at run-time
+type Metrics struct {
+	sync.Mutex
+	Requests  map[string]uint64     // HTTP requests keyed by method, route and status code
+	Latencies map[string]*Histogram // HTTP request latencies keyed by method and route
+	Durations map[string]*Histogram // DBS API durations keyed by API and method
+	Errors    map[string]uint64     // DBS API errors keyed by API, method and DBS error code
+}
+
+// global metrics of the server
+var _metrics = Metrics{
+	Requests:  make(map[string]uint64),
+	Latencies: make(map[string]*Histogram),
+	Durations: make(map[string]*Histogram),
+	Errors:    make(map[string]uint64),
+}
+
+// helper function to observe value of histogram with given labels
+func observe(hmap map[string]*Histogram, labels string, val float64) {
+	h, ok := hmap[labels]
+	if !ok {
+		h = &Histogram{}
+		hmap[labels] = h
+	}
+	h.Observe(val)
+}
+
+// ObserveRequest records HTTP request of given route
+func (m *Metrics) ObserveRequest(method, route string, code int, duration time.Duration) {
+	m.Lock()
+	defer m.Unlock()
+	m.Requests[fmt.Sprintf(`method="%s",route="%s",code="%d"`, method, route, code)]++
+	observe(m.Latencies, fmt.Sprintf(`method="%s",route="%s"`, method, route), duration.Seconds())
+}
+
+// ObserveApi records duration and error of DBS API call
+func (m *Metrics) ObserveApi(api, method string, duration time.Duration, err error) {
+	m.Lock()
+	defer m.Unlock()
+	observe(m.Durations, fmt.Sprintf(`api="%s",method="%s"`, api, method), duration.Seconds())
+	if err != nil {
+		code := dbs.GenericErrorCode
+		var dbsError *dbs.DBSError
+		if errors.As(err, &dbsError) {
+			code = dbsError.Code
+		}
+		m.Errors[fmt.Sprintf(`api="%s",method="%s",code="%d"`, api, method, code)]++
+	}
+}
+
+// MetricsMiddleware provides gin middleware which collects HTTP request metrics
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		// use route pattern instead of request path to keep number of labels bounded
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		_metrics.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// MetricsHandler provides access to GET /metrics end-point which reports
+// server metrics in prometheus text format
+func MetricsHandler(c *gin.Context) {
+	c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte(promMetrics()))
+}
+
+// helper function to write prometheus metric along with its help and type
+func promMetric(out *strings.Builder, name, mtype, help string, val interface{}) {
+	out.WriteString(fmt.Sprintf("# HELP %s %s\n", name, help))
+	out.WriteString(fmt.Sprintf("# TYPE %s %s\n", name, mtype))
+	out.WriteString(fmt.Sprintf("%s %v\n", name, val))
+}
+
+// helper function to write labeled prometheus counters
+func promCounters(out *strings.Builder, name, help string, counters map[string]uint64) {
+	out.WriteString(fmt.Sprintf("# HELP %s %s\n", name, help))
+	out.WriteString(fmt.Sprintf("# TYPE %s counter\n", name))
+	for _, labels := range sortedKeys(counters) {
+		out.WriteString(fmt.Sprintf("%s{%s} %d\n", name, labels, counters[labels]))
+	}
+}
+
+// helper function to write labeled prometheus histograms
+func promHistograms(out *strings.Builder, name, help string, hmap map[string]*Histogram) {
+	out.WriteString(fmt.Sprintf("# HELP %s %s\n", name, help))
+	out.WriteString(fmt.Sprintf("# TYPE %s histogram\n", name))
+	for _, labels := range sortedKeys(hmap) {
+		h := hmap[labels]
+		for i, bound := range MetricsBuckets {
+			out.WriteString(fmt.Sprintf("%s_bucket{%s,le=\"%v\"} %d\n", name, labels, bound, h.Counts[i]))
+		}
+		out.WriteString(fmt.Sprintf("%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, h.Count))
+		out.WriteString(fmt.Sprintf("%s_sum{%s} %v\n", name, labels, h.Sum))
+		out.WriteString(fmt.Sprintf("%s_count{%s} %d\n", name, labels, h.Count))
+	}
+}
+
+// helper function to get sorted keys of a map
+func sortedKeys[T any](rmap map[string]T) []string {
+	var keys []string
+	for k := range rmap {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// helper function to generate server metrics in prometheus text format
+func promMetrics() string {
+	var out strings.Builder
+
+	// process metrics
+	proc := utils.ProcFSMetrics()
+	promMetric(&out, "dbs_process_cpu_seconds_total", "counter", "Total user and system CPU time of the process in seconds", proc.CpuTotal)
+	promMetric(&out, "dbs_process_virtual_memory_bytes", "gauge", "Virtual memory size of the process in bytes", proc.Vsize)
+	promMetric(&out, "dbs_process_virtual_memory_max_bytes", "gauge", "Maximum amount of virtual memory of the process in bytes", proc.MaxVsize)
+	promMetric(&out, "dbs_process_resident_memory_bytes", "gauge", "Resident memory size of the process in bytes", proc.Rss)
+	promMetric(&out, "dbs_process_open_fds", "gauge", "Number of open file descriptors of the process", proc.OpenFDs)
+	promMetric(&out, "dbs_process_max_fds", "gauge", "Maximum number of open file descriptors of the process", proc.MaxFDs)
+	promMetric(&out, "dbs_node_cpu_user_seconds_total", "counter", "Total user CPU time of the node in seconds", proc.SumUserCPUs)
+	promMetric(&out, "dbs_node_cpu_system_seconds_total", "counter", "Total system CPU time of the node in seconds", proc.SumSystemCPUs)
+	out.WriteString("# HELP dbs_node_cpu_seconds_total CPU time of the node per CPU and mode in seconds\n")
+	out.WriteString("# TYPE dbs_node_cpu_seconds_total counter\n")
+	for i, val := range proc.UserCPUs {
+		out.WriteString(fmt.Sprintf("dbs_node_cpu_seconds_total{cpu=\"%d\",mode=\"user\"} %v\n", i, val))
+	}
+	for i, val := range proc.SystemCPUs {
+		out.WriteString(fmt.Sprintf("dbs_node_cpu_seconds_total{cpu=\"%d\",mode=\"system\"} %v\n", i, val))
+	}
+
+	// database connection pool metrics
+	pool := dbs.GetPoolStats()
+	promMetric(&out, "dbs_db_max_open_connections", "gauge", "Maximum number of open connections to the database", pool.MaxOpenConnections)
+	promMetric(&out, "dbs_db_open_connections", "gauge", "Number of established connections to the database", pool.OpenConnections)
+	promMetric(&out, "dbs_db_in_use_connections", "gauge", "Number of connections currently in use", pool.InUse)
+	promMetric(&out, "dbs_db_idle_connections", "gauge", "Number of idle connections", pool.Idle)
+	promMetric(&out, "dbs_db_wait_count_total", "counter", "Total number of connections waited for", pool.WaitCount)
+	promMetric(&out, "dbs_db_wait_duration_seconds_total", "counter", "Total time blocked waiting for a new connection in seconds", float64(pool.WaitDuration)/1000)
+	promMetric(&out, "dbs_db_max_idle_closed_total", "counter", "Total number of connections closed due to max idle connections", pool.MaxIdleClosed)
+	promMetric(&out, "dbs_db_max_idle_time_closed_total", "counter", "Total number of connections closed due to max idle time", pool.MaxIdleTimeClosed)
+	promMetric(&out, "dbs_db_max_lifetime_closed_total", "counter", "Total number of connections closed due to max lifetime", pool.MaxLifetimeClosed)
+
+	// HTTP and DBS API metrics
+	_metrics.Lock()
+	defer _metrics.Unlock()
+	promCounters(&out, "dbs_http_requests_total", "Total number of HTTP requests", _metrics.Requests)
+	promHistograms(&out, "dbs_http_request_duration_seconds", "HTTP request latencies in seconds", _metrics.Latencies)
+	promHistograms(&out, "dbs_api_duration_seconds", "DBS API durations in seconds", _metrics.Durations)
+	promCounters(&out, "dbs_api_errors_total", "Total number of DBS API errors by DBS error code", _metrics.Errors)
+	return out.String()
+}
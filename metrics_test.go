@@ -0,0 +1,61 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestHistogram tests that histogram buckets are cumulative
+func TestHistogram(t *testing.T) {
+	h := Histogram{}
+	for _, val := range []float64{0.001, 0.03, 0.03, 20} {
+		h.Observe(val)
+	}
+	expect := []uint64{1, 1, 1, 3, 3, 3, 3, 3, 3, 3, 3}
+	if !reflect.DeepEqual(h.Counts, expect) || h.Count != 4 || h.Sum != 20.061 {
+		t.Errorf("wrong histogram %+v", h)
+	}
+}
+
+// TestMetrics tests prometheus metrics of HTTP requests, DBS APIs and
+// database connection pool
+func TestMetrics(t *testing.T) {
+	r := initTestServer(t)
+	checkStatus(t, serveRequest(r, "GET", "/datasets", "", nil), http.StatusOK)
+	checkStatus(t, serveRequest(r, "GET", "/dataset/x/y/z?fields=bogus", "", nil), http.StatusBadRequest)
+	checkStatus(t, serveRequest(r, "GET", "/bogus", "", nil), http.StatusNotFound)
+
+	w := serveRequest(r, "GET", "/metrics", "", nil)
+	checkStatus(t, w, http.StatusOK)
+	if ctype := w.Header().Get("Content-Type"); !strings.HasPrefix(ctype, "text/plain; version=0.0.4") {
+		t.Errorf("wrong content type of metrics %s", ctype)
+	}
+	metrics := w.Body.String()
+	for _, line := range []string{
+		"# TYPE dbs_process_cpu_seconds_total counter",
+		"# TYPE dbs_db_open_connections gauge",
+		"# TYPE dbs_http_request_duration_seconds histogram",
+		`dbs_http_requests_total{method="GET",route="/datasets",code="200"} `,
+		`dbs_http_requests_total{method="GET",route="/dataset/*name",code="400"} `,
+		`dbs_http_requests_total{method="GET",route="unmatched",code="404"} `,
+		`dbs_http_request_duration_seconds_bucket{method="GET",route="/datasets",le="+Inf"} `,
+		`dbs_http_request_duration_seconds_count{method="GET",route="/datasets"} `,
+		`dbs_api_duration_seconds_bucket{api="dataset",method="GET",le="0.005"} `,
+		`dbs_api_errors_total{api="dataset",method="GET",code="118"} `,
+	} {
+		if !strings.Contains(metrics, line) {
+			t.Errorf("metrics do not contain '%s'", line)
+		}
+	}
+	// every metric has help and type lines along with valid sample lines
+	for _, line := range strings.Split(strings.TrimSpace(metrics), "\n") {
+		if strings.HasPrefix(line, "# HELP ") || strings.HasPrefix(line, "# TYPE ") {
+			continue
+		}
+		if fields := strings.Fields(line); len(fields) != 2 || !strings.HasPrefix(fields[0], "dbs_") {
+			t.Errorf("invalid metric line '%s'", line)
+		}
+	}
+}
@@ -18,6 +18,8 @@ package main
 //   _ "github.com/go-sql-driver/mysql"
 // SQLite driver:
 //  _ "github.com/mattn/go-sqlite3"
+// PostgreSQL driver:
+//  _ "github.com/lib/pq"
 //
 // Get profile's output
 // visit http://localhost:<port>/debug/pprof
@@ -26,10 +28,17 @@ package main
 // go tool pprof -png http://localhost:<port>/debug/pprof/profile > /tmp/profile.png
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/OreCast/DataBookkeeping/dbs"
 	"github.com/OreCast/DataBookkeeping/utils"
@@ -43,6 +52,8 @@ import (
 	// imports for supported DB drivers
 	// go-sqlite driver
 	_ "github.com/mattn/go-sqlite3"
+	// PostgreSQL driver
+	_ "github.com/lib/pq"
 )
 
 func setupRouter() *gin.Engine {
@@ -50,15 +61,38 @@ func setupRouter() *gin.Engine {
 	// gin.DisableConsoleColor()
 	r := gin.Default()
 
+	r.Use(MetricsMiddleware())
+
+	// health and monitoring routes
+	r.GET("/metrics", MetricsHandler)
+	r.GET("/healthz", HealthzHandler)
+	r.GET("/readyz", ReadyzHandler)
+	r.GET("/info", InfoHandler)
+	r.GET("/lexicon", LexiconHandler)
+
 	// GET routes
 	r.GET("/datasets", DatasetHandler)
 	r.GET("/files", FileHandler)
+	r.GET("/sites", SiteHandler)
+	r.GET("/buckets", BucketHandler)
+	r.GET("/processing", ProcessingHandler)
+	r.GET("/parents", ParentHandler)
 
 	// individual routes
 	r.GET("/dataset", DatasetHandler)
 	r.GET("/dataset/*name", DatasetHandler)
 	r.GET("/file", FileHandler)
 	r.GET("/file/*name", FileHandler)
+	r.GET("/site", SiteHandler)
+	r.GET("/site/*name", SiteHandler)
+	r.GET("/bucket", BucketHandler)
+	r.GET("/bucket/*name", BucketHandler)
+	r.GET("/processing/*name", ProcessingHandler)
+	r.GET("/parent", ParentHandler)
+	r.GET("/parent/*name", ParentHandler)
+	r.GET("/lineage/*name", LineageHandler)
+	r.GET("/datasetsummary", DatasetSummaryHandler)
+	r.GET("/datasetsummary/*name", DatasetSummaryHandler)
 
 	// all POST/PUT/DELET methods ahould be authorized
 	authorized := r.Group("/")
@@ -67,21 +101,46 @@ func setupRouter() *gin.Engine {
 		// POST routes
 		authorized.POST("/dataset", DatasetHandler)
 		authorized.POST("/file", FileHandler)
+		authorized.POST("/site", SiteHandler)
+		authorized.POST("/bucket", BucketHandler)
+		authorized.POST("/processing", ProcessingHandler)
+		authorized.POST("/parent", ParentHandler)
 
 		// PUT routes
 		authorized.PUT("/dataset/*name", DatasetHandler)
+		authorized.PUT("/file", FileHandler)
 		authorized.PUT("/file/*name", FileHandler)
+		authorized.PUT("/site/*name", SiteHandler)
+		authorized.PUT("/bucket/*name", BucketHandler)
+		authorized.PUT("/processing/*name", ProcessingHandler)
+		authorized.PUT("/parent/*name", ParentHandler)
 
 		// DELETE routes
 		authorized.DELETE("/dataset/*name", DatasetHandler)
+		authorized.DELETE("/file", FileHandler)
 		authorized.DELETE("/file/*name", FileHandler)
+		authorized.DELETE("/site/*name", SiteHandler)
+		authorized.DELETE("/bucket/*name", BucketHandler)
+		authorized.DELETE("/processing/*name", ProcessingHandler)
+		authorized.DELETE("/parent/*name", ParentHandler)
 	}
 
+	// keep list of routes to report enabled APIs
+	_routes = r.Routes()
 	return r
 }
 
 // helper function to initialize DB access
 func dbInit(dbtype, dburi string) (*sql.DB, error) {
+	// enable PRAGMA foreign_keys for every connection of sqlite3 pool,
+	// the pragma is a per-connection setting which is off by default
+	if dbtype == "sqlite3" && !strings.Contains(dburi, "_foreign_keys") && !strings.Contains(dburi, "_fk=") {
+		sep := "?"
+		if strings.Contains(dburi, "?") {
+			sep = "&"
+		}
+		dburi = fmt.Sprintf("%s%s_foreign_keys=1", dburi, sep)
+	}
 	db, dberr := sql.Open(dbtype, dburi)
 	if dberr != nil {
 		log.Printf("unable to open %s, error %v", dbtype, dburi)
@@ -104,7 +163,8 @@ func dbInit(dbtype, dburi string) (*sql.DB, error) {
 	return db, nil
 }
 
-func Server() {
+// helper function to initialize DBS settings and DB connection
+func initDBS() {
 	// be verbose
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
@@ -118,9 +178,16 @@ func Server() {
 	if strings.HasPrefix(dbtype, "oci") {
 		utils.ORACLE = true
 	}
+	// for postgres driver we know it is PostgreSQL backend
+	if dbtype == "postgres" {
+		utils.POSTGRES = true
+	}
 	log.Println("DBOWNER", dbowner)
-	// set static dir
+	// set static dir, its files override ones embedded into the server
 	utils.STATICDIR = _oreConfig.DataBookkeeping.WebServer.StaticDir
+	if utils.STATICDIR != "" {
+		log.Println("static files override area", utils.STATICDIR)
+	}
 	utils.VERBOSE = _oreConfig.DataBookkeeping.WebServer.Verbose
 
 	// setup DBS
@@ -130,13 +197,175 @@ func Server() {
 	}
 	dbs.DB = db
 	dbs.DBTYPE = dbtype
+	// load SQL templates, the server exits if any template is invalid
 	dbsql := dbs.LoadSQL(dbowner)
 	dbs.DBSQL = dbsql
 	dbs.DBOWNER = dbowner
-	defer dbs.DB.Close()
+}
+
+// Migrate migrates database schema to given version, either version
+// number or latest
+func Migrate(version string) {
+	initDBS()
+	closeDB(migrateSchema(version))
+}
+
+// helper function to migrate database schema to given version
+func migrateSchema(version string) error {
+	target := -1
+	if version != "latest" {
+		val, err := strconv.Atoi(version)
+		if err != nil || val < 0 {
+			return fmt.Errorf("invalid schema version '%s', should be latest or version number", version)
+		}
+		target = val
+	}
+	if err := dbs.Migrate(target); err != nil {
+		return err
+	}
+	current, err := dbs.SchemaVersion(context.Background())
+	if err != nil {
+		return err
+	}
+	log.Println("database schema version", current)
+	return nil
+}
+
+// InitDB creates DBS schema in empty database along with reference data
+func InitDB() {
+	initDBS()
+	closeDB(initSchema())
+}
+
+// helper function to create DBS schema in empty database
+func initSchema() error {
+	if err := dbs.InitDB(); err != nil {
+		return err
+	}
+	current, err := dbs.SchemaVersion(context.Background())
+	if err != nil {
+		return err
+	}
+	log.Println("database schema version", current)
+	return nil
+}
+
+// helper function to close the database and exit if given error is not nil.
+// The database is closed explicitly since log.Fatal does not run deferred
+// calls, e.g. it would skip SQLite WAL checkpoint.
+func closeDB(err error) {
+	if cerr := dbs.Close(); cerr != nil {
+		log.Println(cerr)
+	}
+	log.Println("database is closed")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// ServerTimeouts represents timeouts of HTTP server
+type ServerTimeouts struct {
+	Read     time.Duration // maximum duration of reading entire request
+	Write    time.Duration // maximum duration before timing out writes of response
+	Idle     time.Duration // maximum time to wait for next request on keep-alive connections
+	Shutdown time.Duration // maximum time to drain in-flight requests on shutdown
+}
+
+// server timeouts, they can be adjusted via command line options
+var _timeouts = ServerTimeouts{
+	Read:     time.Minute,
+	Write:    5 * time.Minute,
+	Idle:     2 * time.Minute,
+	Shutdown: 30 * time.Second,
+}
+
+// interval of checks of lexicon file changes, zero disables the checks
+var _lexiconInterval = 10 * time.Second
+
+// Server starts HTTP server and serves requests until it receives SIGTERM
+// or SIGINT signal. On shutdown the server stops accepting new connections,
+// waits for in-flight requests to complete and closes the database.
+// The SIGHUP signal reloads OreCast lexicon.
+func Server() {
+	initDBS()
+	closeDB(serve())
+}
+
+// helper function to prepare database and lexicon and serve HTTP requests
+// until termination signal, it returns error if server can not be started
+// or fails
+func serve() error {
+	// bootstrap new database on first start
+	empty, err := dbs.IsEmptyDB()
+	if err != nil {
+		return err
+	}
+	if empty {
+		if err := dbs.InitDB(); err != nil {
+			return err
+		}
+	}
+
+	// refuse to serve database with outdated schema
+	if err := dbs.CheckSchemaVersion(context.Background()); err != nil {
+		return err
+	}
+
+	// make sure that database is accessible and has its reference data
+	if err := dbs.GetTestData(context.Background()); err != nil {
+		return err
+	}
+
+	// load OreCast lexicon and reload it when lexicon file is changed
+	if err := dbs.LoadLexicon(); err != nil {
+		return err
+	}
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	go dbs.WatchLexicon(watchCtx, _lexiconInterval)
 
 	r := setupRouter()
 	sport := fmt.Sprintf(":%d", _oreConfig.DataBookkeeping.WebServer.Port)
-	log.Printf("Start HTTP server %s", sport)
-	r.Run(sport)
+	srv := &http.Server{
+		Addr:         sport,
+		Handler:      r,
+		ReadTimeout:  _timeouts.Read,
+		WriteTimeout: _timeouts.Write,
+		IdleTimeout:  _timeouts.Idle,
+	}
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("Start HTTP server %s", sport)
+		serverErr <- srv.ListenAndServe()
+	}()
+
+	// wait for termination signal or server failure, reload lexicon on SIGHUP
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(quit)
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	defer signal.Stop(reload)
+	for running := true; running; {
+		select {
+		case err := <-serverErr:
+			return fmt.Errorf("HTTP server error: %w", err)
+		case <-reload:
+			log.Println("received SIGHUP signal, reload lexicon")
+			if err := dbs.LoadLexicon(); err != nil {
+				log.Println("unable to reload lexicon, keep active one", err)
+			}
+		case sig := <-quit:
+			log.Printf("received %v signal, shutdown HTTP server", sig)
+			running = false
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), _timeouts.Shutdown)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Println("unable to drain in-flight requests", err)
+	} else {
+		log.Println("HTTP server is stopped")
+	}
+	return nil
 }
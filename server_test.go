@@ -0,0 +1,23 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/OreCast/DataBookkeeping/dbs"
+)
+
+// TestServeErrors tests that server start-up failures are returned to the
+// caller which closes the database before exit
+func TestServeErrors(t *testing.T) {
+	initTestServer(t)
+	lexiconFile := dbs.LexiconFile
+	t.Cleanup(func() { dbs.LexiconFile = lexiconFile })
+	dbs.LexiconFile = filepath.Join(t.TempDir(), "lexicon.json")
+	if err := serve(); err == nil {
+		t.Fatal("server is started without lexicon")
+	}
+	if err := dbs.DB.Ping(); err != nil {
+		t.Errorf("database is closed by serve: %v", err)
+	}
+}
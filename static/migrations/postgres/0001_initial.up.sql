@@ -0,0 +1,87 @@
+--------------------------------------------------------
+--  DDL for Sequences
+--------------------------------------------------------
+
+CREATE SEQUENCE SEQ_DS START WITH 1 INCREMENT BY 1;
+CREATE SEQUENCE SEQ_FL START WITH 1 INCREMENT BY 1;
+--------------------------------------------------------
+--  DDL for Table PROCESSING
+--------------------------------------------------------
+
+CREATE TABLE PROCESSING (
+    PROCESSING_ID BIGINT,
+    PROCESSING VARCHAR(700) NOT NULL UNIQUE,
+    CREATION_DATE BIGINT,
+    CREATE_BY VARCHAR(500),
+    LAST_MODIFICATION_DATE BIGINT,
+    LAST_MODIFIED_BY VARCHAR(500)
+);
+--------------------------------------------------------
+--  DDL for Table PARENTS
+--------------------------------------------------------
+
+CREATE TABLE PARENTS (
+    PARENT_ID BIGINT,
+    PARENT VARCHAR(700) NOT NULL UNIQUE,
+    CREATION_DATE BIGINT,
+    CREATE_BY VARCHAR(500),
+    LAST_MODIFICATION_DATE BIGINT,
+    LAST_MODIFIED_BY VARCHAR(500)
+);
+--------------------------------------------------------
+--  DDL for Table SITES
+--------------------------------------------------------
+
+CREATE TABLE SITES (
+    SITE_ID BIGINT,
+    SITE VARCHAR(700) NOT NULL UNIQUE,
+    CREATION_DATE BIGINT,
+    CREATE_BY VARCHAR(500),
+    LAST_MODIFICATION_DATE BIGINT,
+    LAST_MODIFIED_BY VARCHAR(500)
+);
+--------------------------------------------------------
+--  DDL for Table BUCKETS
+--------------------------------------------------------
+
+CREATE TABLE BUCKETS (
+    BUCKET_ID BIGINT,
+    BUCKET VARCHAR(700) NOT NULL UNIQUE,
+    META_ID VARCHAR(700),
+    DATASET_ID BIGINT NOT NULL UNIQUE,
+    CREATION_DATE BIGINT,
+    CREATE_BY VARCHAR(500),
+    LAST_MODIFICATION_DATE BIGINT,
+    LAST_MODIFIED_BY VARCHAR(500)
+);
+--------------------------------------------------------
+--  DDL for Table DATASETS
+--------------------------------------------------------
+
+CREATE TABLE DATASETS (
+    DATASET_ID BIGINT,
+    DATASET VARCHAR(700) NOT NULL UNIQUE,
+    META_ID VARCHAR(700),
+    SITE_ID BIGINT,
+    PROCESSING_ID BIGINT,
+    PARENT_ID BIGINT,
+    CREATION_DATE BIGINT,
+    CREATE_BY VARCHAR(500),
+    LAST_MODIFICATION_DATE BIGINT,
+    LAST_MODIFIED_BY VARCHAR(500)
+);
+--------------------------------------------------------
+--  DDL for Table FILES
+--------------------------------------------------------
+
+CREATE TABLE FILES (
+    FILE_ID BIGINT,
+    LOGICAL_FILE_NAME VARCHAR(700) NOT NULL UNIQUE,
+    IS_FILE_VALID INTEGER DEFAULT 1,
+    DATASET_ID BIGINT,
+    META_ID VARCHAR(700),
+    CREATION_DATE BIGINT,
+    CREATE_BY VARCHAR(500),
+    LAST_MODIFICATION_DATE BIGINT,
+    LAST_MODIFIED_BY VARCHAR(500)
+);
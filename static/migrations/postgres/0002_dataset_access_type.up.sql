@@ -0,0 +1,26 @@
+--------------------------------------------------------
+--  DDL for Table DATASET_ACCESS_TYPES
+--------------------------------------------------------
+
+CREATE TABLE DATASET_ACCESS_TYPES (
+    DATASET_ACCESS_TYPE_ID BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    DATASET_ACCESS_TYPE VARCHAR(100) NOT NULL UNIQUE
+);
+
+--------------------------------------------------------
+--  Reference data
+--------------------------------------------------------
+
+INSERT INTO DATASET_ACCESS_TYPES (DATASET_ACCESS_TYPE) VALUES
+    ('VALID'), ('INVALID'), ('PRODUCTION'), ('DEPRECATED'), ('DELETED');
+
+--------------------------------------------------------
+--  Access type of table DATASETS, existing datasets are valid
+--------------------------------------------------------
+
+ALTER TABLE DATASETS ADD COLUMN DATASET_ACCESS_TYPE_ID BIGINT
+    REFERENCES DATASET_ACCESS_TYPES (DATASET_ACCESS_TYPE_ID);
+UPDATE DATASETS SET DATASET_ACCESS_TYPE_ID = (
+    SELECT DATASET_ACCESS_TYPE_ID FROM DATASET_ACCESS_TYPES
+    WHERE DATASET_ACCESS_TYPE = 'VALID');
+CREATE INDEX IDX_DATASETS_DATASET_ACCESS_TYPE_ID ON DATASETS (DATASET_ACCESS_TYPE_ID);
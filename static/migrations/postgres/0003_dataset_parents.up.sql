@@ -0,0 +1,11 @@
+--------------------------------------------------------
+--  DDL for Table DATASET_PARENTS
+--------------------------------------------------------
+
+CREATE TABLE DATASET_PARENTS (
+    THIS_DATASET_ID BIGINT NOT NULL,
+    PARENT_DATASET_ID BIGINT NOT NULL,
+    CREATION_DATE BIGINT,
+    CREATE_BY VARCHAR(500),
+    UNIQUE (THIS_DATASET_ID, PARENT_DATASET_ID)
+);
@@ -0,0 +1,7 @@
+ALTER TABLE FILES
+    DROP COLUMN IF EXISTS EVENT_COUNT,
+    DROP COLUMN IF EXISTS CONTENT_TYPE,
+    DROP COLUMN IF EXISTS SHA256,
+    DROP COLUMN IF EXISTS MD5,
+    DROP COLUMN IF EXISTS ADLER32,
+    DROP COLUMN IF EXISTS FILE_SIZE;
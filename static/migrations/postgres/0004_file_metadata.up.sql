@@ -0,0 +1,11 @@
+--------------------------------------------------------
+--  Size, checksums, content type and event count of table FILES
+--------------------------------------------------------
+
+ALTER TABLE FILES
+    ADD COLUMN FILE_SIZE BIGINT,
+    ADD COLUMN ADLER32 VARCHAR(100),
+    ADD COLUMN MD5 VARCHAR(100),
+    ADD COLUMN SHA256 VARCHAR(100),
+    ADD COLUMN CONTENT_TYPE VARCHAR(200),
+    ADD COLUMN EVENT_COUNT BIGINT;
@@ -0,0 +1,28 @@
+--------------------------------------------------------
+--  Sequences of dataset and file ids
+--------------------------------------------------------
+CREATE SEQUENCE IF NOT EXISTS SEQ_DS START WITH 1 INCREMENT BY 1;
+CREATE SEQUENCE IF NOT EXISTS SEQ_FL START WITH 1 INCREMENT BY 1;
+SELECT setval('seq_ds', COALESCE(MAX(DATASET_ID), 0) + 1, false) FROM DATASETS;
+SELECT setval('seq_fl', COALESCE(MAX(FILE_ID), 0) + 1, false) FROM FILES;
+--------------------------------------------------------
+--  Drop identity primary keys
+--------------------------------------------------------
+ALTER TABLE PROCESSING ALTER COLUMN PROCESSING_ID DROP IDENTITY IF EXISTS;
+ALTER TABLE PROCESSING DROP CONSTRAINT IF EXISTS processing_pkey;
+ALTER TABLE PROCESSING ALTER COLUMN PROCESSING_ID DROP NOT NULL;
+ALTER TABLE PARENTS ALTER COLUMN PARENT_ID DROP IDENTITY IF EXISTS;
+ALTER TABLE PARENTS DROP CONSTRAINT IF EXISTS parents_pkey;
+ALTER TABLE PARENTS ALTER COLUMN PARENT_ID DROP NOT NULL;
+ALTER TABLE SITES ALTER COLUMN SITE_ID DROP IDENTITY IF EXISTS;
+ALTER TABLE SITES DROP CONSTRAINT IF EXISTS sites_pkey;
+ALTER TABLE SITES ALTER COLUMN SITE_ID DROP NOT NULL;
+ALTER TABLE BUCKETS ALTER COLUMN BUCKET_ID DROP IDENTITY IF EXISTS;
+ALTER TABLE BUCKETS DROP CONSTRAINT IF EXISTS buckets_pkey;
+ALTER TABLE BUCKETS ALTER COLUMN BUCKET_ID DROP NOT NULL;
+ALTER TABLE DATASETS ALTER COLUMN DATASET_ID DROP IDENTITY IF EXISTS;
+ALTER TABLE DATASETS DROP CONSTRAINT IF EXISTS datasets_pkey;
+ALTER TABLE DATASETS ALTER COLUMN DATASET_ID DROP NOT NULL;
+ALTER TABLE FILES ALTER COLUMN FILE_ID DROP IDENTITY IF EXISTS;
+ALTER TABLE FILES DROP CONSTRAINT IF EXISTS files_pkey;
+ALTER TABLE FILES ALTER COLUMN FILE_ID DROP NOT NULL;
@@ -0,0 +1,26 @@
+--------------------------------------------------------
+--  Identity primary keys
+--------------------------------------------------------
+ALTER TABLE PROCESSING ADD PRIMARY KEY (PROCESSING_ID);
+ALTER TABLE PROCESSING ALTER COLUMN PROCESSING_ID ADD GENERATED BY DEFAULT AS IDENTITY;
+SELECT setval(pg_get_serial_sequence('processing', 'processing_id'), COALESCE(MAX(PROCESSING_ID), 0) + 1, false) FROM PROCESSING;
+ALTER TABLE PARENTS ADD PRIMARY KEY (PARENT_ID);
+ALTER TABLE PARENTS ALTER COLUMN PARENT_ID ADD GENERATED BY DEFAULT AS IDENTITY;
+SELECT setval(pg_get_serial_sequence('parents', 'parent_id'), COALESCE(MAX(PARENT_ID), 0) + 1, false) FROM PARENTS;
+ALTER TABLE SITES ADD PRIMARY KEY (SITE_ID);
+ALTER TABLE SITES ALTER COLUMN SITE_ID ADD GENERATED BY DEFAULT AS IDENTITY;
+SELECT setval(pg_get_serial_sequence('sites', 'site_id'), COALESCE(MAX(SITE_ID), 0) + 1, false) FROM SITES;
+ALTER TABLE BUCKETS ADD PRIMARY KEY (BUCKET_ID);
+ALTER TABLE BUCKETS ALTER COLUMN BUCKET_ID ADD GENERATED BY DEFAULT AS IDENTITY;
+SELECT setval(pg_get_serial_sequence('buckets', 'bucket_id'), COALESCE(MAX(BUCKET_ID), 0) + 1, false) FROM BUCKETS;
+ALTER TABLE DATASETS ADD PRIMARY KEY (DATASET_ID);
+ALTER TABLE DATASETS ALTER COLUMN DATASET_ID ADD GENERATED BY DEFAULT AS IDENTITY;
+SELECT setval(pg_get_serial_sequence('datasets', 'dataset_id'), COALESCE(MAX(DATASET_ID), 0) + 1, false) FROM DATASETS;
+ALTER TABLE FILES ADD PRIMARY KEY (FILE_ID);
+ALTER TABLE FILES ALTER COLUMN FILE_ID ADD GENERATED BY DEFAULT AS IDENTITY;
+SELECT setval(pg_get_serial_sequence('files', 'file_id'), COALESCE(MAX(FILE_ID), 0) + 1, false) FROM FILES;
+--------------------------------------------------------
+--  Drop sequences replaced by identity columns
+--------------------------------------------------------
+DROP SEQUENCE IF EXISTS SEQ_DS;
+DROP SEQUENCE IF EXISTS SEQ_FL;
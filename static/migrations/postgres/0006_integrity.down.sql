@@ -0,0 +1,41 @@
+--------------------------------------------------------
+--  Drop indexes of join columns
+--------------------------------------------------------
+
+DROP INDEX IF EXISTS IDX_DATASET_PARENTS_PARENT_DATASET_ID;
+DROP INDEX IF EXISTS IDX_DATASET_BUCKETS_BUCKET_ID;
+DROP INDEX IF EXISTS IDX_FILES_DATASET_ID;
+DROP INDEX IF EXISTS IDX_DATASETS_PARENT_ID;
+DROP INDEX IF EXISTS IDX_DATASETS_PROCESSING_ID;
+DROP INDEX IF EXISTS IDX_DATASETS_SITE_ID;
+
+--------------------------------------------------------
+--  Drop foreign keys
+--------------------------------------------------------
+
+ALTER TABLE DATASET_PARENTS DROP CONSTRAINT IF EXISTS FK_DATASET_PARENTS_PARENT_DATASET_ID;
+ALTER TABLE DATASET_PARENTS DROP CONSTRAINT IF EXISTS FK_DATASET_PARENTS_THIS_DATASET_ID;
+ALTER TABLE DATASET_PARENTS DROP CONSTRAINT IF EXISTS dataset_parents_pkey;
+ALTER TABLE DATASET_PARENTS ADD UNIQUE (THIS_DATASET_ID, PARENT_DATASET_ID);
+ALTER TABLE FILES DROP CONSTRAINT IF EXISTS FK_FILES_DATASET_ID;
+ALTER TABLE FILES ALTER COLUMN DATASET_ID DROP NOT NULL;
+ALTER TABLE DATASETS DROP CONSTRAINT IF EXISTS FK_DATASETS_PARENT_ID;
+ALTER TABLE DATASETS DROP CONSTRAINT IF EXISTS FK_DATASETS_PROCESSING_ID;
+ALTER TABLE DATASETS DROP CONSTRAINT IF EXISTS FK_DATASETS_SITE_ID;
+ALTER TABLE DATASETS ALTER COLUMN PROCESSING_ID DROP NOT NULL;
+ALTER TABLE DATASETS ALTER COLUMN SITE_ID DROP NOT NULL;
+UPDATE DATASETS SET PARENT_ID = 0 WHERE PARENT_ID IS NULL;
+
+--------------------------------------------------------
+--  Restore dataset of table BUCKETS, the bucket keeps its first dataset
+--  and buckets which can't be assigned to a single dataset are dropped
+--------------------------------------------------------
+
+ALTER TABLE BUCKETS ADD COLUMN DATASET_ID BIGINT;
+UPDATE BUCKETS B SET DATASET_ID = (
+    SELECT MIN(DB.DATASET_ID) FROM DATASET_BUCKETS DB WHERE DB.BUCKET_ID = B.BUCKET_ID);
+DELETE FROM BUCKETS B WHERE B.DATASET_ID IS NULL OR EXISTS (
+    SELECT 1 FROM BUCKETS O WHERE O.DATASET_ID = B.DATASET_ID AND O.BUCKET_ID < B.BUCKET_ID);
+ALTER TABLE BUCKETS ALTER COLUMN DATASET_ID SET NOT NULL;
+ALTER TABLE BUCKETS ADD UNIQUE (DATASET_ID);
+DROP TABLE DATASET_BUCKETS;
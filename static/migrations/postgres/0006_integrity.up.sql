@@ -0,0 +1,54 @@
+--------------------------------------------------------
+--  DDL for Table DATASET_BUCKETS
+--------------------------------------------------------
+
+CREATE TABLE DATASET_BUCKETS (
+    DATASET_ID BIGINT NOT NULL,
+    BUCKET_ID BIGINT NOT NULL,
+    CREATION_DATE BIGINT,
+    CREATE_BY VARCHAR(500),
+    PRIMARY KEY (DATASET_ID, BUCKET_ID)
+);
+INSERT INTO DATASET_BUCKETS
+    SELECT B.DATASET_ID, B.BUCKET_ID, B.CREATION_DATE, B.CREATE_BY
+    FROM BUCKETS B
+    JOIN DATASETS D on D.DATASET_ID = B.DATASET_ID;
+ALTER TABLE BUCKETS DROP COLUMN DATASET_ID;
+
+--------------------------------------------------------
+--  Foreign keys, zero parent id stands for dataset without parent
+--------------------------------------------------------
+
+UPDATE DATASETS SET PARENT_ID = NULL WHERE PARENT_ID = 0;
+ALTER TABLE DATASETS ALTER COLUMN SITE_ID SET NOT NULL;
+ALTER TABLE DATASETS ALTER COLUMN PROCESSING_ID SET NOT NULL;
+ALTER TABLE DATASETS ADD CONSTRAINT FK_DATASETS_SITE_ID
+    FOREIGN KEY (SITE_ID) REFERENCES SITES (SITE_ID);
+ALTER TABLE DATASETS ADD CONSTRAINT FK_DATASETS_PROCESSING_ID
+    FOREIGN KEY (PROCESSING_ID) REFERENCES PROCESSING (PROCESSING_ID);
+ALTER TABLE DATASETS ADD CONSTRAINT FK_DATASETS_PARENT_ID
+    FOREIGN KEY (PARENT_ID) REFERENCES PARENTS (PARENT_ID);
+ALTER TABLE FILES ALTER COLUMN DATASET_ID SET NOT NULL;
+ALTER TABLE FILES ADD CONSTRAINT FK_FILES_DATASET_ID
+    FOREIGN KEY (DATASET_ID) REFERENCES DATASETS (DATASET_ID);
+ALTER TABLE DATASET_BUCKETS ADD CONSTRAINT FK_DATASET_BUCKETS_DATASET_ID
+    FOREIGN KEY (DATASET_ID) REFERENCES DATASETS (DATASET_ID);
+ALTER TABLE DATASET_BUCKETS ADD CONSTRAINT FK_DATASET_BUCKETS_BUCKET_ID
+    FOREIGN KEY (BUCKET_ID) REFERENCES BUCKETS (BUCKET_ID);
+ALTER TABLE DATASET_PARENTS DROP CONSTRAINT dataset_parents_this_dataset_id_parent_dataset_id_key;
+ALTER TABLE DATASET_PARENTS ADD PRIMARY KEY (THIS_DATASET_ID, PARENT_DATASET_ID);
+ALTER TABLE DATASET_PARENTS ADD CONSTRAINT FK_DATASET_PARENTS_THIS_DATASET_ID
+    FOREIGN KEY (THIS_DATASET_ID) REFERENCES DATASETS (DATASET_ID);
+ALTER TABLE DATASET_PARENTS ADD CONSTRAINT FK_DATASET_PARENTS_PARENT_DATASET_ID
+    FOREIGN KEY (PARENT_DATASET_ID) REFERENCES DATASETS (DATASET_ID);
+
+--------------------------------------------------------
+--  Indexes of join columns
+--------------------------------------------------------
+
+CREATE INDEX IDX_DATASETS_SITE_ID ON DATASETS (SITE_ID);
+CREATE INDEX IDX_DATASETS_PROCESSING_ID ON DATASETS (PROCESSING_ID);
+CREATE INDEX IDX_DATASETS_PARENT_ID ON DATASETS (PARENT_ID);
+CREATE INDEX IDX_FILES_DATASET_ID ON FILES (DATASET_ID);
+CREATE INDEX IDX_DATASET_BUCKETS_BUCKET_ID ON DATASET_BUCKETS (BUCKET_ID);
+CREATE INDEX IDX_DATASET_PARENTS_PARENT_DATASET_ID ON DATASET_PARENTS (PARENT_DATASET_ID);
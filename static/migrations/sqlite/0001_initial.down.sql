@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "FILES";
+DROP TABLE IF EXISTS "DATASETS";
+DROP TABLE IF EXISTS "BUCKETS";
+DROP TABLE IF EXISTS "SITES";
+DROP TABLE IF EXISTS "PARENTS";
+DROP TABLE IF EXISTS "PROCESSING";
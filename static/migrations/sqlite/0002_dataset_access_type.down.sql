@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "IDX_DATASETS_DATASET_ACCESS_TYPE_ID";
+ALTER TABLE "DATASETS" DROP COLUMN "DATASET_ACCESS_TYPE_ID";
+DROP TABLE IF EXISTS "DATASET_ACCESS_TYPES";
@@ -0,0 +1,29 @@
+--------------------------------------------------------
+--  DDL for Table DATASET_ACCESS_TYPES
+--------------------------------------------------------
+
+CREATE TABLE "DATASET_ACCESS_TYPES" (
+    "DATASET_ACCESS_TYPE_ID" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "DATASET_ACCESS_TYPE" VARCHAR2(100) NOT NULL UNIQUE
+);
+
+--------------------------------------------------------
+--  Reference data
+--------------------------------------------------------
+
+INSERT INTO "DATASET_ACCESS_TYPES" ("DATASET_ACCESS_TYPE") VALUES ('VALID');
+INSERT INTO "DATASET_ACCESS_TYPES" ("DATASET_ACCESS_TYPE") VALUES ('INVALID');
+INSERT INTO "DATASET_ACCESS_TYPES" ("DATASET_ACCESS_TYPE") VALUES ('PRODUCTION');
+INSERT INTO "DATASET_ACCESS_TYPES" ("DATASET_ACCESS_TYPE") VALUES ('DEPRECATED');
+INSERT INTO "DATASET_ACCESS_TYPES" ("DATASET_ACCESS_TYPE") VALUES ('DELETED');
+
+--------------------------------------------------------
+--  Access type of table DATASETS, existing datasets are valid
+--------------------------------------------------------
+
+ALTER TABLE "DATASETS" ADD COLUMN "DATASET_ACCESS_TYPE_ID" INTEGER
+    REFERENCES "DATASET_ACCESS_TYPES" ("DATASET_ACCESS_TYPE_ID");
+UPDATE "DATASETS" SET "DATASET_ACCESS_TYPE_ID" = (
+    SELECT "DATASET_ACCESS_TYPE_ID" FROM "DATASET_ACCESS_TYPES"
+    WHERE "DATASET_ACCESS_TYPE" = 'VALID');
+CREATE INDEX "IDX_DATASETS_DATASET_ACCESS_TYPE_ID" ON "DATASETS" ("DATASET_ACCESS_TYPE_ID");
@@ -0,0 +1,11 @@
+--------------------------------------------------------
+--  DDL for Table DATASET_PARENTS
+--------------------------------------------------------
+
+CREATE TABLE "DATASET_PARENTS" (
+    "THIS_DATASET_ID" INTEGER NOT NULL,
+    "PARENT_DATASET_ID" INTEGER NOT NULL,
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    UNIQUE ("THIS_DATASET_ID", "PARENT_DATASET_ID")
+);
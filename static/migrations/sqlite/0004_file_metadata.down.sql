@@ -0,0 +1,6 @@
+ALTER TABLE "FILES" DROP COLUMN "EVENT_COUNT";
+ALTER TABLE "FILES" DROP COLUMN "CONTENT_TYPE";
+ALTER TABLE "FILES" DROP COLUMN "SHA256";
+ALTER TABLE "FILES" DROP COLUMN "MD5";
+ALTER TABLE "FILES" DROP COLUMN "ADLER32";
+ALTER TABLE "FILES" DROP COLUMN "FILE_SIZE";
@@ -0,0 +1,10 @@
+--------------------------------------------------------
+--  Size, checksums, content type and event count of table FILES
+--------------------------------------------------------
+
+ALTER TABLE "FILES" ADD COLUMN "FILE_SIZE" INTEGER;
+ALTER TABLE "FILES" ADD COLUMN "ADLER32" VARCHAR2(100);
+ALTER TABLE "FILES" ADD COLUMN "MD5" VARCHAR2(100);
+ALTER TABLE "FILES" ADD COLUMN "SHA256" VARCHAR2(100);
+ALTER TABLE "FILES" ADD COLUMN "CONTENT_TYPE" VARCHAR2(200);
+ALTER TABLE "FILES" ADD COLUMN "EVENT_COUNT" INTEGER;
@@ -0,0 +1,144 @@
+--------------------------------------------------------
+--  Drop primary key of table PROCESSING
+--------------------------------------------------------
+
+CREATE TABLE "PROCESSING_NEW" (
+    "PROCESSING_ID" INTEGER,
+    "PROCESSING" VARCHAR2(700) NOT NULL UNIQUE,
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    "LAST_MODIFICATION_DATE" INTEGER,
+    "LAST_MODIFIED_BY" VARCHAR2(500)
+);
+INSERT INTO "PROCESSING_NEW" ("PROCESSING_ID", "PROCESSING",
+    "CREATION_DATE", "CREATE_BY", "LAST_MODIFICATION_DATE",
+    "LAST_MODIFIED_BY")
+    SELECT "PROCESSING_ID", "PROCESSING", "CREATION_DATE", "CREATE_BY",
+        "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY"
+    FROM "PROCESSING";
+DROP TABLE "PROCESSING";
+ALTER TABLE "PROCESSING_NEW" RENAME TO "PROCESSING";
+
+--------------------------------------------------------
+--  Drop primary key of table PARENTS
+--------------------------------------------------------
+
+CREATE TABLE "PARENTS_NEW" (
+    "PARENT_ID" INTEGER,
+    "PARENT" VARCHAR2(700) NOT NULL UNIQUE,
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    "LAST_MODIFICATION_DATE" INTEGER,
+    "LAST_MODIFIED_BY" VARCHAR2(500)
+);
+INSERT INTO "PARENTS_NEW" ("PARENT_ID", "PARENT", "CREATION_DATE",
+    "CREATE_BY", "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY")
+    SELECT "PARENT_ID", "PARENT", "CREATION_DATE", "CREATE_BY",
+        "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY"
+    FROM "PARENTS";
+DROP TABLE "PARENTS";
+ALTER TABLE "PARENTS_NEW" RENAME TO "PARENTS";
+
+--------------------------------------------------------
+--  Drop primary key of table SITES
+--------------------------------------------------------
+
+CREATE TABLE "SITES_NEW" (
+    "SITE_ID" INTEGER,
+    "SITE" VARCHAR2(700) NOT NULL UNIQUE,
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    "LAST_MODIFICATION_DATE" INTEGER,
+    "LAST_MODIFIED_BY" VARCHAR2(500)
+);
+INSERT INTO "SITES_NEW" ("SITE_ID", "SITE", "CREATION_DATE", "CREATE_BY",
+    "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY")
+    SELECT "SITE_ID", "SITE", "CREATION_DATE", "CREATE_BY",
+        "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY"
+    FROM "SITES";
+DROP TABLE "SITES";
+ALTER TABLE "SITES_NEW" RENAME TO "SITES";
+
+--------------------------------------------------------
+--  Drop primary key of table BUCKETS
+--------------------------------------------------------
+
+CREATE TABLE "BUCKETS_NEW" (
+    "BUCKET_ID" INTEGER,
+    "BUCKET" VARCHAR2(700) NOT NULL UNIQUE,
+    "META_ID" VARCHAR2(700),
+    "DATASET_ID" VARCHAR2(700) NOT NULL UNIQUE,
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    "LAST_MODIFICATION_DATE" INTEGER,
+    "LAST_MODIFIED_BY" VARCHAR2(500)
+);
+INSERT INTO "BUCKETS_NEW" ("BUCKET_ID", "BUCKET", "META_ID", "DATASET_ID",
+    "CREATION_DATE", "CREATE_BY", "LAST_MODIFICATION_DATE",
+    "LAST_MODIFIED_BY")
+    SELECT "BUCKET_ID", "BUCKET", "META_ID", "DATASET_ID", "CREATION_DATE",
+        "CREATE_BY", "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY"
+    FROM "BUCKETS";
+DROP TABLE "BUCKETS";
+ALTER TABLE "BUCKETS_NEW" RENAME TO "BUCKETS";
+
+--------------------------------------------------------
+--  Drop primary key of table DATASETS
+--------------------------------------------------------
+
+CREATE TABLE "DATASETS_NEW" (
+    "DATASET_ID" INTEGER,
+    "DATASET" VARCHAR2(700) NOT NULL UNIQUE,
+    "META_ID" VARCHAR2(700),
+    "SITE_ID" INTEGER,
+    "PROCESSING_ID" INTEGER,
+    "PARENT_ID" INTEGER,
+    "DATASET_ACCESS_TYPE_ID" INTEGER REFERENCES "DATASET_ACCESS_TYPES" ("DATASET_ACCESS_TYPE_ID"),
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    "LAST_MODIFICATION_DATE" INTEGER,
+    "LAST_MODIFIED_BY" VARCHAR2(500)
+);
+INSERT INTO "DATASETS_NEW" ("DATASET_ID", "DATASET", "META_ID", "SITE_ID",
+    "PROCESSING_ID", "PARENT_ID", "DATASET_ACCESS_TYPE_ID", "CREATION_DATE",
+    "CREATE_BY", "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY")
+    SELECT "DATASET_ID", "DATASET", "META_ID", "SITE_ID", "PROCESSING_ID",
+        "PARENT_ID", "DATASET_ACCESS_TYPE_ID", "CREATION_DATE", "CREATE_BY",
+        "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY"
+    FROM "DATASETS";
+DROP TABLE "DATASETS";
+ALTER TABLE "DATASETS_NEW" RENAME TO "DATASETS";
+CREATE INDEX "IDX_DATASETS_DATASET_ACCESS_TYPE_ID" ON "DATASETS" ("DATASET_ACCESS_TYPE_ID");
+
+--------------------------------------------------------
+--  Drop primary key of table FILES
+--------------------------------------------------------
+
+CREATE TABLE "FILES_NEW" (
+    "FILE_ID" INTEGER,
+    "LOGICAL_FILE_NAME" VARCHAR2(700) NOT NULL UNIQUE,
+    "IS_FILE_VALID" INTEGER DEFAULT 1,
+    "DATASET_ID" INTEGER,
+    "META_ID" VARCHAR2(700),
+    "FILE_SIZE" INTEGER,
+    "ADLER32" VARCHAR2(100),
+    "MD5" VARCHAR2(100),
+    "SHA256" VARCHAR2(100),
+    "CONTENT_TYPE" VARCHAR2(200),
+    "EVENT_COUNT" INTEGER,
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    "LAST_MODIFICATION_DATE" INTEGER,
+    "LAST_MODIFIED_BY" VARCHAR2(500)
+);
+INSERT INTO "FILES_NEW" ("FILE_ID", "LOGICAL_FILE_NAME", "IS_FILE_VALID",
+    "DATASET_ID", "META_ID", "FILE_SIZE", "ADLER32", "MD5", "SHA256",
+    "CONTENT_TYPE", "EVENT_COUNT", "CREATION_DATE", "CREATE_BY",
+    "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY")
+    SELECT "FILE_ID", "LOGICAL_FILE_NAME", "IS_FILE_VALID", "DATASET_ID",
+        "META_ID", "FILE_SIZE", "ADLER32", "MD5", "SHA256", "CONTENT_TYPE",
+        "EVENT_COUNT", "CREATION_DATE", "CREATE_BY",
+        "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY"
+    FROM "FILES";
+DROP TABLE "FILES";
+ALTER TABLE "FILES_NEW" RENAME TO "FILES";
@@ -0,0 +1,108 @@
+--------------------------------------------------------
+--  Drop keys of table DATASET_PARENTS
+--------------------------------------------------------
+
+CREATE TABLE "DATASET_PARENTS_NEW" (
+    "THIS_DATASET_ID" INTEGER NOT NULL,
+    "PARENT_DATASET_ID" INTEGER NOT NULL,
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    UNIQUE ("THIS_DATASET_ID", "PARENT_DATASET_ID")
+);
+INSERT INTO "DATASET_PARENTS_NEW" ("THIS_DATASET_ID", "PARENT_DATASET_ID",
+    "CREATION_DATE", "CREATE_BY")
+    SELECT "THIS_DATASET_ID", "PARENT_DATASET_ID", "CREATION_DATE",
+        "CREATE_BY"
+    FROM "DATASET_PARENTS";
+DROP TABLE "DATASET_PARENTS";
+ALTER TABLE "DATASET_PARENTS_NEW" RENAME TO "DATASET_PARENTS";
+
+--------------------------------------------------------
+--  Drop foreign keys of table FILES
+--------------------------------------------------------
+
+CREATE TABLE "FILES_NEW" (
+    "FILE_ID" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "LOGICAL_FILE_NAME" VARCHAR2(700) NOT NULL UNIQUE,
+    "IS_FILE_VALID" INTEGER DEFAULT 1,
+    "DATASET_ID" INTEGER,
+    "META_ID" VARCHAR2(700),
+    "FILE_SIZE" INTEGER,
+    "ADLER32" VARCHAR2(100),
+    "MD5" VARCHAR2(100),
+    "SHA256" VARCHAR2(100),
+    "CONTENT_TYPE" VARCHAR2(200),
+    "EVENT_COUNT" INTEGER,
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    "LAST_MODIFICATION_DATE" INTEGER,
+    "LAST_MODIFIED_BY" VARCHAR2(500)
+);
+INSERT INTO "FILES_NEW" ("FILE_ID", "LOGICAL_FILE_NAME", "IS_FILE_VALID",
+    "DATASET_ID", "META_ID", "FILE_SIZE", "ADLER32", "MD5", "SHA256",
+    "CONTENT_TYPE", "EVENT_COUNT", "CREATION_DATE", "CREATE_BY",
+    "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY")
+    SELECT "FILE_ID", "LOGICAL_FILE_NAME", "IS_FILE_VALID", "DATASET_ID",
+        "META_ID", "FILE_SIZE", "ADLER32", "MD5", "SHA256", "CONTENT_TYPE",
+        "EVENT_COUNT", "CREATION_DATE", "CREATE_BY",
+        "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY"
+    FROM "FILES";
+DROP TABLE "FILES";
+ALTER TABLE "FILES_NEW" RENAME TO "FILES";
+
+--------------------------------------------------------
+--  Drop foreign keys of table DATASETS
+--------------------------------------------------------
+
+CREATE TABLE "DATASETS_NEW" (
+    "DATASET_ID" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "DATASET" VARCHAR2(700) NOT NULL UNIQUE,
+    "META_ID" VARCHAR2(700),
+    "SITE_ID" INTEGER,
+    "PROCESSING_ID" INTEGER,
+    "PARENT_ID" INTEGER,
+    "DATASET_ACCESS_TYPE_ID" INTEGER REFERENCES "DATASET_ACCESS_TYPES" ("DATASET_ACCESS_TYPE_ID"),
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    "LAST_MODIFICATION_DATE" INTEGER,
+    "LAST_MODIFIED_BY" VARCHAR2(500)
+);
+INSERT INTO "DATASETS_NEW" ("DATASET_ID", "DATASET", "META_ID", "SITE_ID",
+    "PROCESSING_ID", "PARENT_ID", "DATASET_ACCESS_TYPE_ID", "CREATION_DATE",
+    "CREATE_BY", "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY")
+    SELECT "DATASET_ID", "DATASET", "META_ID", "SITE_ID", "PROCESSING_ID",
+        COALESCE("PARENT_ID", 0), "DATASET_ACCESS_TYPE_ID", "CREATION_DATE",
+        "CREATE_BY", "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY"
+    FROM "DATASETS";
+DROP TABLE "DATASETS";
+ALTER TABLE "DATASETS_NEW" RENAME TO "DATASETS";
+CREATE INDEX "IDX_DATASETS_DATASET_ACCESS_TYPE_ID" ON "DATASETS" ("DATASET_ACCESS_TYPE_ID");
+
+--------------------------------------------------------
+--  Restore dataset of table BUCKETS, the bucket keeps its first dataset
+--  and buckets which can't be assigned to a single dataset are dropped
+--------------------------------------------------------
+
+CREATE TABLE "BUCKETS_NEW" (
+    "BUCKET_ID" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "BUCKET" VARCHAR2(700) NOT NULL UNIQUE,
+    "META_ID" VARCHAR2(700),
+    "DATASET_ID" VARCHAR2(700) NOT NULL UNIQUE,
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    "LAST_MODIFICATION_DATE" INTEGER,
+    "LAST_MODIFIED_BY" VARCHAR2(500)
+);
+INSERT OR IGNORE INTO "BUCKETS_NEW" (
+    "BUCKET_ID", "BUCKET", "META_ID", "DATASET_ID", "CREATION_DATE", "CREATE_BY",
+    "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY")
+    SELECT B."BUCKET_ID", B."BUCKET", B."META_ID", MIN(DB."DATASET_ID"),
+        B."CREATION_DATE", B."CREATE_BY",
+        B."LAST_MODIFICATION_DATE", B."LAST_MODIFIED_BY"
+    FROM "BUCKETS" B
+    JOIN "DATASET_BUCKETS" DB on DB."BUCKET_ID" = B."BUCKET_ID"
+    GROUP BY B."BUCKET_ID"
+    ORDER BY B."BUCKET_ID";
+DROP TABLE "BUCKETS";
+ALTER TABLE "BUCKETS_NEW" RENAME TO "BUCKETS";
+DROP TABLE "DATASET_BUCKETS";
@@ -0,0 +1,129 @@
+--------------------------------------------------------
+--  DDL for Table DATASET_BUCKETS
+--------------------------------------------------------
+
+CREATE TABLE "DATASET_BUCKETS" (
+    "DATASET_ID" INTEGER NOT NULL REFERENCES "DATASETS" ("DATASET_ID"),
+    "BUCKET_ID" INTEGER NOT NULL REFERENCES "BUCKETS" ("BUCKET_ID"),
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    PRIMARY KEY ("DATASET_ID", "BUCKET_ID")
+);
+INSERT INTO "DATASET_BUCKETS" ("DATASET_ID", "BUCKET_ID", "CREATION_DATE", "CREATE_BY")
+    SELECT B."DATASET_ID", B."BUCKET_ID", B."CREATION_DATE", B."CREATE_BY"
+    FROM "BUCKETS" B
+    JOIN "DATASETS" D on D."DATASET_ID" = B."DATASET_ID";
+
+--------------------------------------------------------
+--  Drop dataset of table BUCKETS
+--------------------------------------------------------
+
+CREATE TABLE "BUCKETS_NEW" (
+    "BUCKET_ID" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "BUCKET" VARCHAR2(700) NOT NULL UNIQUE,
+    "META_ID" VARCHAR2(700),
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    "LAST_MODIFICATION_DATE" INTEGER,
+    "LAST_MODIFIED_BY" VARCHAR2(500)
+);
+INSERT INTO "BUCKETS_NEW" ("BUCKET_ID", "BUCKET", "META_ID",
+    "CREATION_DATE", "CREATE_BY", "LAST_MODIFICATION_DATE",
+    "LAST_MODIFIED_BY")
+    SELECT "BUCKET_ID", "BUCKET", "META_ID", "CREATION_DATE", "CREATE_BY",
+        "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY"
+    FROM "BUCKETS";
+DROP TABLE "BUCKETS";
+ALTER TABLE "BUCKETS_NEW" RENAME TO "BUCKETS";
+
+--------------------------------------------------------
+--  Foreign keys of table DATASETS, zero parent id stands for dataset
+--  without parent
+--------------------------------------------------------
+
+CREATE TABLE "DATASETS_NEW" (
+    "DATASET_ID" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "DATASET" VARCHAR2(700) NOT NULL UNIQUE,
+    "META_ID" VARCHAR2(700),
+    "SITE_ID" INTEGER NOT NULL REFERENCES "SITES" ("SITE_ID"),
+    "PROCESSING_ID" INTEGER NOT NULL REFERENCES "PROCESSING" ("PROCESSING_ID"),
+    "PARENT_ID" INTEGER REFERENCES "PARENTS" ("PARENT_ID"),
+    "DATASET_ACCESS_TYPE_ID" INTEGER REFERENCES "DATASET_ACCESS_TYPES" ("DATASET_ACCESS_TYPE_ID"),
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    "LAST_MODIFICATION_DATE" INTEGER,
+    "LAST_MODIFIED_BY" VARCHAR2(500)
+);
+INSERT INTO "DATASETS_NEW" ("DATASET_ID", "DATASET", "META_ID", "SITE_ID",
+    "PROCESSING_ID", "PARENT_ID", "DATASET_ACCESS_TYPE_ID", "CREATION_DATE",
+    "CREATE_BY", "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY")
+    SELECT "DATASET_ID", "DATASET", "META_ID", "SITE_ID", "PROCESSING_ID",
+        NULLIF("PARENT_ID", 0), "DATASET_ACCESS_TYPE_ID", "CREATION_DATE",
+        "CREATE_BY", "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY"
+    FROM "DATASETS";
+DROP TABLE "DATASETS";
+ALTER TABLE "DATASETS_NEW" RENAME TO "DATASETS";
+
+--------------------------------------------------------
+--  Foreign keys of table FILES
+--------------------------------------------------------
+
+CREATE TABLE "FILES_NEW" (
+    "FILE_ID" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "LOGICAL_FILE_NAME" VARCHAR2(700) NOT NULL UNIQUE,
+    "IS_FILE_VALID" INTEGER DEFAULT 1,
+    "DATASET_ID" INTEGER NOT NULL REFERENCES "DATASETS" ("DATASET_ID"),
+    "META_ID" VARCHAR2(700),
+    "FILE_SIZE" INTEGER,
+    "ADLER32" VARCHAR2(100),
+    "MD5" VARCHAR2(100),
+    "SHA256" VARCHAR2(100),
+    "CONTENT_TYPE" VARCHAR2(200),
+    "EVENT_COUNT" INTEGER,
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    "LAST_MODIFICATION_DATE" INTEGER,
+    "LAST_MODIFIED_BY" VARCHAR2(500)
+);
+INSERT INTO "FILES_NEW" ("FILE_ID", "LOGICAL_FILE_NAME", "IS_FILE_VALID",
+    "DATASET_ID", "META_ID", "FILE_SIZE", "ADLER32", "MD5", "SHA256",
+    "CONTENT_TYPE", "EVENT_COUNT", "CREATION_DATE", "CREATE_BY",
+    "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY")
+    SELECT "FILE_ID", "LOGICAL_FILE_NAME", "IS_FILE_VALID", "DATASET_ID",
+        "META_ID", "FILE_SIZE", "ADLER32", "MD5", "SHA256", "CONTENT_TYPE",
+        "EVENT_COUNT", "CREATION_DATE", "CREATE_BY",
+        "LAST_MODIFICATION_DATE", "LAST_MODIFIED_BY"
+    FROM "FILES";
+DROP TABLE "FILES";
+ALTER TABLE "FILES_NEW" RENAME TO "FILES";
+
+--------------------------------------------------------
+--  Primary and foreign keys of table DATASET_PARENTS
+--------------------------------------------------------
+
+CREATE TABLE "DATASET_PARENTS_NEW" (
+    "THIS_DATASET_ID" INTEGER NOT NULL REFERENCES "DATASETS" ("DATASET_ID"),
+    "PARENT_DATASET_ID" INTEGER NOT NULL REFERENCES "DATASETS" ("DATASET_ID"),
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    PRIMARY KEY ("THIS_DATASET_ID", "PARENT_DATASET_ID")
+);
+INSERT INTO "DATASET_PARENTS_NEW" ("THIS_DATASET_ID", "PARENT_DATASET_ID",
+    "CREATION_DATE", "CREATE_BY")
+    SELECT "THIS_DATASET_ID", "PARENT_DATASET_ID", "CREATION_DATE",
+        "CREATE_BY"
+    FROM "DATASET_PARENTS";
+DROP TABLE "DATASET_PARENTS";
+ALTER TABLE "DATASET_PARENTS_NEW" RENAME TO "DATASET_PARENTS";
+
+--------------------------------------------------------
+--  Indexes of join columns
+--------------------------------------------------------
+
+CREATE INDEX "IDX_DATASETS_SITE_ID" ON "DATASETS" ("SITE_ID");
+CREATE INDEX "IDX_DATASETS_PROCESSING_ID" ON "DATASETS" ("PROCESSING_ID");
+CREATE INDEX "IDX_DATASETS_PARENT_ID" ON "DATASETS" ("PARENT_ID");
+CREATE INDEX "IDX_DATASETS_DATASET_ACCESS_TYPE_ID" ON "DATASETS" ("DATASET_ACCESS_TYPE_ID");
+CREATE INDEX "IDX_FILES_DATASET_ID" ON "FILES" ("DATASET_ID");
+CREATE INDEX "IDX_DATASET_BUCKETS_BUCKET_ID" ON "DATASET_BUCKETS" ("BUCKET_ID");
+CREATE INDEX "IDX_DATASET_PARENTS_PARENT_DATASET_ID" ON "DATASET_PARENTS" ("PARENT_DATASET_ID");
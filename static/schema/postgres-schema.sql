@@ -0,0 +1,137 @@
+--------------------------------------------------------
+--  DDL for Table DATASET_ACCESS_TYPES
+--------------------------------------------------------
+
+CREATE TABLE DATASET_ACCESS_TYPES (
+    DATASET_ACCESS_TYPE_ID BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    DATASET_ACCESS_TYPE VARCHAR(100) NOT NULL UNIQUE
+);
+--------------------------------------------------------
+--  DDL for Table PROCESSING
+--------------------------------------------------------
+
+CREATE TABLE PROCESSING (
+    PROCESSING_ID BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    PROCESSING VARCHAR(700) NOT NULL UNIQUE,
+    CREATION_DATE BIGINT,
+    CREATE_BY VARCHAR(500),
+    LAST_MODIFICATION_DATE BIGINT,
+    LAST_MODIFIED_BY VARCHAR(500)
+);
+--------------------------------------------------------
+--  DDL for Table PARENTS
+--------------------------------------------------------
+
+CREATE TABLE PARENTS (
+    PARENT_ID BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    PARENT VARCHAR(700) NOT NULL UNIQUE,
+    CREATION_DATE BIGINT,
+    CREATE_BY VARCHAR(500),
+    LAST_MODIFICATION_DATE BIGINT,
+    LAST_MODIFIED_BY VARCHAR(500)
+);
+--------------------------------------------------------
+--  DDL for Table SITES
+--------------------------------------------------------
+
+CREATE TABLE SITES (
+    SITE_ID BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    SITE VARCHAR(700) NOT NULL UNIQUE,
+    CREATION_DATE BIGINT,
+    CREATE_BY VARCHAR(500),
+    LAST_MODIFICATION_DATE BIGINT,
+    LAST_MODIFIED_BY VARCHAR(500)
+);
+--------------------------------------------------------
+--  DDL for Table BUCKETS
+--------------------------------------------------------
+
+CREATE TABLE BUCKETS (
+    BUCKET_ID BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    BUCKET VARCHAR(700) NOT NULL UNIQUE,
+    META_ID VARCHAR(700),
+    CREATION_DATE BIGINT,
+    CREATE_BY VARCHAR(500),
+    LAST_MODIFICATION_DATE BIGINT,
+    LAST_MODIFIED_BY VARCHAR(500)
+);
+--------------------------------------------------------
+--  DDL for Table DATASETS
+--------------------------------------------------------
+
+CREATE TABLE DATASETS (
+    DATASET_ID BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    DATASET VARCHAR(700) NOT NULL UNIQUE,
+    META_ID VARCHAR(700),
+    SITE_ID BIGINT NOT NULL REFERENCES SITES (SITE_ID),
+    PROCESSING_ID BIGINT NOT NULL REFERENCES PROCESSING (PROCESSING_ID),
+    PARENT_ID BIGINT REFERENCES PARENTS (PARENT_ID),
+    DATASET_ACCESS_TYPE_ID BIGINT REFERENCES DATASET_ACCESS_TYPES (DATASET_ACCESS_TYPE_ID),
+    CREATION_DATE BIGINT,
+    CREATE_BY VARCHAR(500),
+    LAST_MODIFICATION_DATE BIGINT,
+    LAST_MODIFIED_BY VARCHAR(500)
+);
+--------------------------------------------------------
+--  DDL for Table FILES
+--------------------------------------------------------
+
+CREATE TABLE FILES (
+    FILE_ID BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    LOGICAL_FILE_NAME VARCHAR(700) NOT NULL UNIQUE,
+    IS_FILE_VALID INTEGER DEFAULT 1,
+    DATASET_ID BIGINT NOT NULL REFERENCES DATASETS (DATASET_ID),
+    META_ID VARCHAR(700),
+    FILE_SIZE BIGINT,
+    ADLER32 VARCHAR(100),
+    MD5 VARCHAR(100),
+    SHA256 VARCHAR(100),
+    CONTENT_TYPE VARCHAR(200),
+    EVENT_COUNT BIGINT,
+    CREATION_DATE BIGINT,
+    CREATE_BY VARCHAR(500),
+    LAST_MODIFICATION_DATE BIGINT,
+    LAST_MODIFIED_BY VARCHAR(500)
+);
+--------------------------------------------------------
+--  DDL for Table DATASET_PARENTS
+--------------------------------------------------------
+
+CREATE TABLE DATASET_PARENTS (
+    THIS_DATASET_ID BIGINT NOT NULL REFERENCES DATASETS (DATASET_ID),
+    PARENT_DATASET_ID BIGINT NOT NULL REFERENCES DATASETS (DATASET_ID),
+    CREATION_DATE BIGINT,
+    CREATE_BY VARCHAR(500),
+    PRIMARY KEY (THIS_DATASET_ID, PARENT_DATASET_ID)
+);
+--------------------------------------------------------
+--  DDL for Table DATASET_BUCKETS
+--------------------------------------------------------
+
+CREATE TABLE DATASET_BUCKETS (
+    DATASET_ID BIGINT NOT NULL REFERENCES DATASETS (DATASET_ID),
+    BUCKET_ID BIGINT NOT NULL REFERENCES BUCKETS (BUCKET_ID),
+    CREATION_DATE BIGINT,
+    CREATE_BY VARCHAR(500),
+    PRIMARY KEY (DATASET_ID, BUCKET_ID)
+);
+--------------------------------------------------------
+--  Indexes of join columns
+--------------------------------------------------------
+
+CREATE INDEX IDX_DATASETS_SITE_ID ON DATASETS (SITE_ID);
+CREATE INDEX IDX_DATASETS_PROCESSING_ID ON DATASETS (PROCESSING_ID);
+CREATE INDEX IDX_DATASETS_PARENT_ID ON DATASETS (PARENT_ID);
+CREATE INDEX IDX_DATASETS_DATASET_ACCESS_TYPE_ID ON DATASETS (DATASET_ACCESS_TYPE_ID);
+CREATE INDEX IDX_FILES_DATASET_ID ON FILES (DATASET_ID);
+CREATE INDEX IDX_DATASET_BUCKETS_BUCKET_ID ON DATASET_BUCKETS (BUCKET_ID);
+CREATE INDEX IDX_DATASET_PARENTS_PARENT_DATASET_ID ON DATASET_PARENTS (PARENT_DATASET_ID);
+--------------------------------------------------------
+--  Reference data
+--------------------------------------------------------
+
+INSERT INTO DATASET_ACCESS_TYPES (DATASET_ACCESS_TYPE) VALUES ('VALID');
+INSERT INTO DATASET_ACCESS_TYPES (DATASET_ACCESS_TYPE) VALUES ('INVALID');
+INSERT INTO DATASET_ACCESS_TYPES (DATASET_ACCESS_TYPE) VALUES ('PRODUCTION');
+INSERT INTO DATASET_ACCESS_TYPES (DATASET_ACCESS_TYPE) VALUES ('DEPRECATED');
+INSERT INTO DATASET_ACCESS_TYPES (DATASET_ACCESS_TYPE) VALUES ('DELETED');
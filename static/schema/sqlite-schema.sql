@@ -1,9 +1,17 @@
 --------------------------------------------------------
+--  DDL for Table DATASET_ACCESS_TYPES
+--------------------------------------------------------
+
+CREATE TABLE "DATASET_ACCESS_TYPES" (
+    "DATASET_ACCESS_TYPE_ID" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "DATASET_ACCESS_TYPE" VARCHAR2(100) NOT NULL UNIQUE
+);
+--------------------------------------------------------
 --  DDL for Table PROCESSING
 --------------------------------------------------------
 
 CREATE TABLE "PROCESSING" (
-    "PROCESSING_ID" INTEGER,
+    "PROCESSING_ID" INTEGER PRIMARY KEY AUTOINCREMENT,
     "PROCESSING" VARCHAR2(700) NOT NULL UNIQUE,
     "CREATION_DATE" INTEGER,
     "CREATE_BY" VARCHAR2(500),
@@ -15,7 +23,7 @@ CREATE TABLE "PROCESSING" (
 --------------------------------------------------------
 
 CREATE TABLE "PARENTS" (
-    "PARENT_ID" INTEGER,
+    "PARENT_ID" INTEGER PRIMARY KEY AUTOINCREMENT,
     "PARENT" VARCHAR2(700) NOT NULL UNIQUE,
     "CREATION_DATE" INTEGER,
     "CREATE_BY" VARCHAR2(500),
@@ -27,7 +35,7 @@ CREATE TABLE "PARENTS" (
 --------------------------------------------------------
 
 CREATE TABLE "SITES" (
-    "SITE_ID" INTEGER,
+    "SITE_ID" INTEGER PRIMARY KEY AUTOINCREMENT,
     "SITE" VARCHAR2(700) NOT NULL UNIQUE,
     "CREATION_DATE" INTEGER,
     "CREATE_BY" VARCHAR2(500),
@@ -39,10 +47,9 @@ CREATE TABLE "SITES" (
 --------------------------------------------------------
 
 CREATE TABLE "BUCKETS" (
-    "BUCKET_ID" INTEGER,
+    "BUCKET_ID" INTEGER PRIMARY KEY AUTOINCREMENT,
     "BUCKET" VARCHAR2(700) NOT NULL UNIQUE,
     "META_ID" VARCHAR2(700),
-    "DATASET_ID" VARCHAR2(700) NOT NULL UNIQUE,
     "CREATION_DATE" INTEGER,
     "CREATE_BY" VARCHAR2(500),
     "LAST_MODIFICATION_DATE" INTEGER,
@@ -53,12 +60,13 @@ CREATE TABLE "BUCKETS" (
 --------------------------------------------------------
 
 CREATE TABLE "DATASETS" (
-    "DATASET_ID" INTEGER,
+    "DATASET_ID" INTEGER PRIMARY KEY AUTOINCREMENT,
     "DATASET" VARCHAR2(700) NOT NULL UNIQUE,
     "META_ID" VARCHAR2(700),
-    "SITE_ID" INTEGER,
-    "PROCESSING_ID" INTEGER,
-    "PARENT_ID" INTEGER,
+    "SITE_ID" INTEGER NOT NULL REFERENCES "SITES" ("SITE_ID"),
+    "PROCESSING_ID" INTEGER NOT NULL REFERENCES "PROCESSING" ("PROCESSING_ID"),
+    "PARENT_ID" INTEGER REFERENCES "PARENTS" ("PARENT_ID"),
+    "DATASET_ACCESS_TYPE_ID" INTEGER REFERENCES "DATASET_ACCESS_TYPES" ("DATASET_ACCESS_TYPE_ID"),
     "CREATION_DATE" INTEGER,
     "CREATE_BY" VARCHAR2(500),
     "LAST_MODIFICATION_DATE" INTEGER,
@@ -69,13 +77,61 @@ CREATE TABLE "DATASETS" (
 --------------------------------------------------------
 
 CREATE TABLE "FILES" (
-    "FILE_ID" INTEGER,
+    "FILE_ID" INTEGER PRIMARY KEY AUTOINCREMENT,
     "LOGICAL_FILE_NAME" VARCHAR2(700) NOT NULL UNIQUE,
     "IS_FILE_VALID" INTEGER DEFAULT 1,
-    "DATASET_ID" INTEGER,
+    "DATASET_ID" INTEGER NOT NULL REFERENCES "DATASETS" ("DATASET_ID"),
     "META_ID" VARCHAR2(700),
+    "FILE_SIZE" INTEGER,
+    "ADLER32" VARCHAR2(100),
+    "MD5" VARCHAR2(100),
+    "SHA256" VARCHAR2(100),
+    "CONTENT_TYPE" VARCHAR2(200),
+    "EVENT_COUNT" INTEGER,
     "CREATION_DATE" INTEGER,
     "CREATE_BY" VARCHAR2(500),
     "LAST_MODIFICATION_DATE" INTEGER,
     "LAST_MODIFIED_BY" VARCHAR2(500)
 );
+--------------------------------------------------------
+--  DDL for Table DATASET_PARENTS
+--------------------------------------------------------
+
+CREATE TABLE "DATASET_PARENTS" (
+    "THIS_DATASET_ID" INTEGER NOT NULL REFERENCES "DATASETS" ("DATASET_ID"),
+    "PARENT_DATASET_ID" INTEGER NOT NULL REFERENCES "DATASETS" ("DATASET_ID"),
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    PRIMARY KEY ("THIS_DATASET_ID", "PARENT_DATASET_ID")
+);
+--------------------------------------------------------
+--  DDL for Table DATASET_BUCKETS
+--------------------------------------------------------
+
+CREATE TABLE "DATASET_BUCKETS" (
+    "DATASET_ID" INTEGER NOT NULL REFERENCES "DATASETS" ("DATASET_ID"),
+    "BUCKET_ID" INTEGER NOT NULL REFERENCES "BUCKETS" ("BUCKET_ID"),
+    "CREATION_DATE" INTEGER,
+    "CREATE_BY" VARCHAR2(500),
+    PRIMARY KEY ("DATASET_ID", "BUCKET_ID")
+);
+--------------------------------------------------------
+--  Indexes of join columns
+--------------------------------------------------------
+
+CREATE INDEX "IDX_DATASETS_SITE_ID" ON "DATASETS" ("SITE_ID");
+CREATE INDEX "IDX_DATASETS_PROCESSING_ID" ON "DATASETS" ("PROCESSING_ID");
+CREATE INDEX "IDX_DATASETS_PARENT_ID" ON "DATASETS" ("PARENT_ID");
+CREATE INDEX "IDX_DATASETS_DATASET_ACCESS_TYPE_ID" ON "DATASETS" ("DATASET_ACCESS_TYPE_ID");
+CREATE INDEX "IDX_FILES_DATASET_ID" ON "FILES" ("DATASET_ID");
+CREATE INDEX "IDX_DATASET_BUCKETS_BUCKET_ID" ON "DATASET_BUCKETS" ("BUCKET_ID");
+CREATE INDEX "IDX_DATASET_PARENTS_PARENT_DATASET_ID" ON "DATASET_PARENTS" ("PARENT_DATASET_ID");
+--------------------------------------------------------
+--  Reference data
+--------------------------------------------------------
+
+INSERT INTO "DATASET_ACCESS_TYPES" ("DATASET_ACCESS_TYPE") VALUES ('VALID');
+INSERT INTO "DATASET_ACCESS_TYPES" ("DATASET_ACCESS_TYPE") VALUES ('INVALID');
+INSERT INTO "DATASET_ACCESS_TYPES" ("DATASET_ACCESS_TYPE") VALUES ('PRODUCTION');
+INSERT INTO "DATASET_ACCESS_TYPES" ("DATASET_ACCESS_TYPE") VALUES ('DEPRECATED');
+INSERT INTO "DATASET_ACCESS_TYPES" ("DATASET_ACCESS_TYPE") VALUES ('DELETED');
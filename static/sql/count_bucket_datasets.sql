@@ -0,0 +1 @@
+SELECT COUNT(DB.DATASET_ID) FROM DATASET_BUCKETS DB WHERE DB.BUCKET_ID=:bucket_id
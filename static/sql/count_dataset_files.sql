@@ -0,0 +1 @@
+SELECT COUNT(F.FILE_ID) FROM FILES F WHERE F.DATASET_ID=:dataset_id
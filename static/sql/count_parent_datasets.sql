@@ -0,0 +1 @@
+SELECT COUNT(D.DATASET_ID) FROM DATASETS D WHERE D.PARENT_ID=:parent_id
@@ -0,0 +1 @@
+SELECT COUNT(D.DATASET_ID) FROM DATASETS D WHERE D.SITE_ID=:site_id
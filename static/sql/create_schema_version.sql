@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS SCHEMA_VERSION (
+    VERSION INTEGER NOT NULL PRIMARY KEY,
+    NAME VARCHAR(700) NOT NULL,
+    APPLIED_DATE BIGINT
+)
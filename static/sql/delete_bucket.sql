@@ -0,0 +1 @@
+DELETE FROM BUCKETS WHERE bucket_id=:bucket_id
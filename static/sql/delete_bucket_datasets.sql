@@ -0,0 +1 @@
+DELETE FROM DATASET_BUCKETS WHERE bucket_id=:bucket_id
@@ -0,0 +1 @@
+DELETE FROM DATASETS WHERE dataset_id=:dataset_id
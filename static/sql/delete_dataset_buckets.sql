@@ -0,0 +1 @@
+DELETE FROM DATASET_BUCKETS WHERE dataset_id=:dataset_id
@@ -0,0 +1 @@
+DELETE FROM FILES WHERE logical_file_name=:logical_file_name AND dataset_id=:dataset_id
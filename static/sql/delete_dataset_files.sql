@@ -0,0 +1 @@
+DELETE FROM FILES WHERE dataset_id=:dataset_id
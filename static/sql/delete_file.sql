@@ -0,0 +1 @@
+DELETE FROM FILES WHERE file_id=:file_id
@@ -0,0 +1 @@
+DELETE FROM DATASET_PARENTS WHERE this_dataset_id=:this_dataset_id
@@ -0,0 +1 @@
+DELETE FROM DATASET_PARENTS WHERE parent_dataset_id=:parent_dataset_id
@@ -0,0 +1 @@
+DELETE FROM PARENTS WHERE parent_id=:parent_id
@@ -0,0 +1 @@
+DELETE FROM PROCESSING WHERE processing_id=:processing_id
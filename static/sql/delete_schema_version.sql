@@ -0,0 +1 @@
+DELETE FROM SCHEMA_VERSION WHERE VERSION=:version
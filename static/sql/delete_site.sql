@@ -0,0 +1 @@
+DELETE FROM SITES WHERE site_id=:site_id
@@ -1,8 +1,10 @@
 INSERT INTO DATASETS
     (dataset_id,dataset,meta_id,site_id,processing_id,parent_id,
+     dataset_access_type_id,
      creation_date,create_by,
      last_modification_date,last_modified_by)
     VALUES
     (:dataset_id,:dataset,:meta_id,:site_id,:processing_id,:parent_id,
+     (SELECT dataset_access_type_id FROM DATASET_ACCESS_TYPES WHERE dataset_access_type='VALID'),
      :creation_date,:create_by,
      :last_modification_date,:last_modified_by)
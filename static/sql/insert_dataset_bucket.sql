@@ -0,0 +1,6 @@
+INSERT INTO DATASET_BUCKETS
+    (dataset_id,bucket_id,
+     creation_date,create_by)
+    VALUES
+    (:dataset_id,:bucket_id,
+     :creation_date,:create_by)
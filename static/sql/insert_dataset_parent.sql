@@ -0,0 +1,6 @@
+INSERT INTO DATASET_PARENTS
+    (this_dataset_id,parent_dataset_id,
+     creation_date,create_by)
+    VALUES
+    (:this_dataset_id,:parent_dataset_id,
+     :creation_date,:create_by)
@@ -0,0 +1 @@
+INSERT INTO SCHEMA_VERSION (VERSION, NAME, APPLIED_DATE) VALUES (:version, :name, :applied_date)
@@ -1 +1,9 @@
-SELECT * FROM BUCKETS B;
+SELECT
+    B.BUCKET_ID,
+    B.BUCKET,
+    B.META_ID,
+    B.CREATION_DATE,
+    B.CREATE_BY,
+    B.LAST_MODIFICATION_DATE,
+    B.LAST_MODIFIED_BY
+FROM BUCKETS B
@@ -0,0 +1,2 @@
+SELECT DB.BUCKET_ID FROM DATASET_BUCKETS DB
+JOIN DATASETS D on D.DATASET_ID=DB.DATASET_ID
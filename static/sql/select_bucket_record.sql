@@ -0,0 +1,10 @@
+SELECT
+    B.BUCKET_ID,
+    B.BUCKET,
+    B.META_ID,
+    B.CREATION_DATE,
+    B.CREATE_BY,
+    B.LAST_MODIFICATION_DATE,
+    B.LAST_MODIFIED_BY
+FROM BUCKETS B
+WHERE B.BUCKET=:bucket
@@ -1,9 +1,11 @@
 SELECT
+    D.DATASET_ID,
     D.DATASET,
     D.META_ID,
     S.SITE,
     PR.PROCESSING,
-    P.PARENT,
+    DPS.PARENT_DATASET,
+    DA.DATASET_ACCESS_TYPE,
     D.CREATE_BY,
     D.CREATION_DATE,
     D.LAST_MODIFIED_BY,
@@ -11,4 +13,10 @@ SELECT
 FROM DATASETS D
 JOIN SITES S on S.SITE_ID=D.SITE_ID
 JOIN PROCESSING PR on PR.PROCESSING_ID=D.PROCESSING_ID
-LEFT OUTER JOIN PARENTS P on P.PARENT_ID=D.PARENT_ID
+LEFT OUTER JOIN DATASET_ACCESS_TYPES DA on DA.DATASET_ACCESS_TYPE_ID=D.DATASET_ACCESS_TYPE_ID
+LEFT OUTER JOIN (
+    SELECT DP.THIS_DATASET_ID, {{.ParentDatasets}} AS PARENT_DATASET
+    FROM DATASET_PARENTS DP
+    JOIN DATASETS PD on PD.DATASET_ID=DP.PARENT_DATASET_ID
+    GROUP BY DP.THIS_DATASET_ID
+) DPS on DPS.THIS_DATASET_ID=D.DATASET_ID
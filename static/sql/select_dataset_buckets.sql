@@ -0,0 +1,3 @@
+SELECT B.BUCKET FROM BUCKETS B
+JOIN DATASET_BUCKETS DB on DB.BUCKET_ID=B.BUCKET_ID
+WHERE DB.DATASET_ID=:dataset_id
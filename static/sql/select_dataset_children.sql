@@ -0,0 +1,4 @@
+SELECT C.DATASET FROM DATASET_PARENTS DP
+JOIN DATASETS C on C.DATASET_ID=DP.THIS_DATASET_ID
+JOIN DATASETS D on D.DATASET_ID=DP.PARENT_DATASET_ID
+WHERE D.DATASET=:dataset
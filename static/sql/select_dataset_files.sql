@@ -0,0 +1 @@
+SELECT F.LOGICAL_FILE_NAME FROM FILES F WHERE F.DATASET_ID=:dataset_id
@@ -0,0 +1 @@
+SELECT D.DATASET FROM DATASETS D WHERE D.DATASET_ID=:dataset_id
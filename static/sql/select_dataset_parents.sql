@@ -0,0 +1,4 @@
+SELECT P.DATASET FROM DATASET_PARENTS DP
+JOIN DATASETS P on P.DATASET_ID=DP.PARENT_DATASET_ID
+JOIN DATASETS D on D.DATASET_ID=DP.THIS_DATASET_ID
+WHERE D.DATASET=:dataset
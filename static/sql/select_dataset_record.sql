@@ -0,0 +1,13 @@
+SELECT
+    D.DATASET_ID,
+    D.DATASET,
+    D.META_ID,
+    D.SITE_ID,
+    D.PROCESSING_ID,
+    D.PARENT_ID,
+    D.CREATION_DATE,
+    D.CREATE_BY,
+    D.LAST_MODIFICATION_DATE,
+    D.LAST_MODIFIED_BY
+FROM DATASETS D
+WHERE D.DATASET=:dataset
@@ -0,0 +1,14 @@
+SELECT
+    DS.DATASET,
+    DS.SITE,
+    DS.PROCESSING,
+    COUNT(F.FILE_ID) AS NFILES,
+    SUM(CASE WHEN F.IS_FILE_VALID=1 THEN 1 ELSE 0 END) AS NVALID_FILES,
+    SUM(F.FILE_SIZE) AS TOTAL_SIZE,
+    MIN(F.CREATION_DATE) AS FIRST_CREATION_DATE,
+    MAX(F.CREATION_DATE) AS LAST_CREATION_DATE
+FROM ({{.Datasets}}) DS
+JOIN DATASETS D on D.DATASET=DS.DATASET
+LEFT OUTER JOIN FILES F on F.DATASET_ID=D.DATASET_ID
+GROUP BY DS.DATASET, DS.SITE, DS.PROCESSING
+ORDER BY DS.DATASET
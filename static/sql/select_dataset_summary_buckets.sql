@@ -0,0 +1,8 @@
+SELECT
+    DS.DATASET,
+    B.BUCKET
+FROM ({{.Datasets}}) DS
+JOIN DATASETS D on D.DATASET=DS.DATASET
+JOIN DATASET_BUCKETS DB on DB.DATASET_ID=D.DATASET_ID
+JOIN BUCKETS B on B.BUCKET_ID=DB.BUCKET_ID
+ORDER BY DS.DATASET, B.BUCKET
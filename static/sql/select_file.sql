@@ -1,2 +1,19 @@
-SELECT * FROM FILES F
+SELECT
+    F.FILE_ID,
+    F.LOGICAL_FILE_NAME,
+    F.IS_FILE_VALID,
+    F.DATASET_ID,
+    D.DATASET,
+    F.META_ID,
+    F.FILE_SIZE,
+    F.ADLER32,
+    F.MD5,
+    F.SHA256,
+    F.CONTENT_TYPE,
+    F.EVENT_COUNT,
+    F.CREATION_DATE,
+    F.CREATE_BY,
+    F.LAST_MODIFICATION_DATE,
+    F.LAST_MODIFIED_BY
+FROM FILES F
 JOIN DATASETS D on D.DATASET_ID = F.DATASET_ID
@@ -0,0 +1,12 @@
+SELECT
+    F.FILE_ID,
+    F.LOGICAL_FILE_NAME,
+    F.IS_FILE_VALID,
+    F.DATASET_ID,
+    F.META_ID,
+    F.CREATION_DATE,
+    F.CREATE_BY,
+    F.LAST_MODIFICATION_DATE,
+    F.LAST_MODIFIED_BY
+FROM FILES F
+WHERE F.LOGICAL_FILE_NAME=:logical_file_name
@@ -1 +1,9 @@
-SELECT * FROM PARENT PR;
+SELECT DISTINCT
+    P.PARENT_ID,
+    P.PARENT,
+    P.CREATION_DATE,
+    P.CREATE_BY,
+    P.LAST_MODIFICATION_DATE,
+    P.LAST_MODIFIED_BY
+FROM PARENTS P
+LEFT OUTER JOIN DATASETS D on D.PARENT_ID=P.PARENT_ID
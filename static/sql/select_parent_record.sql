@@ -0,0 +1,9 @@
+SELECT
+    P.PARENT_ID,
+    P.PARENT,
+    P.CREATION_DATE,
+    P.CREATE_BY,
+    P.LAST_MODIFICATION_DATE,
+    P.LAST_MODIFIED_BY
+FROM PARENTS P
+WHERE P.PARENT=:parent
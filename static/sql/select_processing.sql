@@ -1 +1,9 @@
-SELECT * FROM PROCESSING P;
+SELECT DISTINCT
+    PR.PROCESSING_ID,
+    PR.PROCESSING,
+    PR.CREATION_DATE,
+    PR.CREATE_BY,
+    PR.LAST_MODIFICATION_DATE,
+    PR.LAST_MODIFIED_BY
+FROM PROCESSING PR
+LEFT OUTER JOIN DATASETS D on D.PROCESSING_ID=PR.PROCESSING_ID
@@ -0,0 +1,9 @@
+SELECT
+    P.PROCESSING_ID,
+    P.PROCESSING,
+    P.CREATION_DATE,
+    P.CREATE_BY,
+    P.LAST_MODIFICATION_DATE,
+    P.LAST_MODIFIED_BY
+FROM PROCESSING P
+WHERE P.PROCESSING=:processing
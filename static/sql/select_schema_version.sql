@@ -0,0 +1 @@
+SELECT MAX(SV.VERSION) FROM SCHEMA_VERSION SV
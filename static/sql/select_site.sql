@@ -1 +1,9 @@
-SELECT * FROM SITES S;
+SELECT DISTINCT
+    S.SITE_ID,
+    S.SITE,
+    S.CREATION_DATE,
+    S.CREATE_BY,
+    S.LAST_MODIFICATION_DATE,
+    S.LAST_MODIFIED_BY
+FROM SITES S
+LEFT OUTER JOIN DATASETS D on D.SITE_ID=S.SITE_ID
@@ -0,0 +1,9 @@
+SELECT
+    S.SITE_ID,
+    S.SITE,
+    S.CREATION_DATE,
+    S.CREATE_BY,
+    S.LAST_MODIFICATION_DATE,
+    S.LAST_MODIFIED_BY
+FROM SITES S
+WHERE S.SITE=:site
@@ -0,0 +1,3 @@
+SELECT DT.DATASET_ACCESS_TYPE
+FROM {{.Owner}}.DATASET_ACCESS_TYPES DT
+WHERE DT.DATASET_ACCESS_TYPE=:dataset_access_type
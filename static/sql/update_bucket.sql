@@ -0,0 +1,6 @@
+UPDATE BUCKETS SET
+    bucket=:bucket,
+    meta_id=:meta_id,
+    last_modification_date=:last_modification_date,
+    last_modified_by=:last_modified_by
+WHERE bucket_id=:bucket_id
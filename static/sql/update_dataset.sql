@@ -0,0 +1,8 @@
+UPDATE DATASETS SET
+    meta_id=:meta_id,
+    site_id=:site_id,
+    processing_id=:processing_id,
+    parent_id=:parent_id,
+    last_modification_date=:last_modification_date,
+    last_modified_by=:last_modified_by
+WHERE dataset_id=:dataset_id
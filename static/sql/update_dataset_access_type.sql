@@ -0,0 +1,6 @@
+UPDATE DATASETS SET
+    dataset_access_type_id=(SELECT DA.dataset_access_type_id FROM DATASET_ACCESS_TYPES DA
+        WHERE DA.dataset_access_type=:dataset_access_type),
+    last_modification_date=:last_modification_date,
+    last_modified_by=:last_modified_by
+WHERE dataset_id=:dataset_id
@@ -0,0 +1,7 @@
+UPDATE FILES SET
+    is_file_valid=:is_file_valid,
+    dataset_id=:dataset_id,
+    meta_id=:meta_id,
+    last_modification_date=:last_modification_date,
+    last_modified_by=:last_modified_by
+WHERE file_id=:file_id
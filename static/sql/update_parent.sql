@@ -0,0 +1,5 @@
+UPDATE PARENTS SET
+    parent=:parent,
+    last_modification_date=:last_modification_date,
+    last_modified_by=:last_modified_by
+WHERE parent_id=:parent_id
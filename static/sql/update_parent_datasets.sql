@@ -0,0 +1 @@
+UPDATE DATASETS SET parent_id=NULL WHERE parent_id=:parent_id
@@ -0,0 +1,5 @@
+UPDATE PROCESSING SET
+    processing=:processing,
+    last_modification_date=:last_modification_date,
+    last_modified_by=:last_modified_by
+WHERE processing_id=:processing_id
@@ -0,0 +1,5 @@
+UPDATE SITES SET
+    site=:site,
+    last_modification_date=:last_modification_date,
+    last_modified_by=:last_modified_by
+WHERE site_id=:site_id
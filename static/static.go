@@ -0,0 +1,10 @@
+// Package static provides static files of DBS server, i.e. SQL templates,
+// database schemas, schema migrations and OreCast lexicon, embedded into the binary.
+package static
+
+import "embed"
+
+// FS holds embedded sql, schema and migrations areas and lexicon of DBS server
+//
+//go:embed sql schema migrations lexicon.json
+var FS embed.FS
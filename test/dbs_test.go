@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/OreCast/DataBookkeeping/dbs"
+	"github.com/OreCast/DataBookkeeping/utils"
+	validator "github.com/go-playground/validator/v10"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// number of concurrent inserts used by tests
+var nInserts = 20
+
+// helper function to initialize DBS test database. It uses database provided
+// by DBS_DB_FILE environment, otherwise the database is created in temporary
+// area. The empty database is bootstrapped in the same way as done by server.
+func initDB(t *testing.T) {
+	utils.STATICDIR = "../static"
+	dbs.RecordValidator = validator.New()
+	dbfile := os.Getenv("DBS_DB_FILE")
+	if dbfile == "" {
+		dbfile = filepath.Join(t.TempDir(), "dbs-test.db")
+	}
+	// busy timeout allows concurrent transactions to wait for SQLite write lock
+	db, err := sql.Open("sqlite3", fmt.Sprintf("%s?_busy_timeout=30000&_foreign_keys=1", dbfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	db.Exec("PRAGMA journal_mode=WAL;")
+	dbs.DB = db
+	dbs.DBTYPE = "sqlite3"
+	dbs.DBOWNER = "sqlite"
+	dbs.DBSQL = dbs.LoadSQL("sqlite")
+	empty, err := dbs.IsEmptyDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty {
+		if err := dbs.InitDB(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// helper function to run given insert function concurrently, each insert is
+// performed in its own transaction. It returns ids of inserted records.
+func insertParallel(t *testing.T, insert func(tx *dbs.Tx, i int) (int64, error)) []int64 {
+	ids := make([]int64, nInserts)
+	errs := make([]error, nInserts)
+	var wg sync.WaitGroup
+	for i := 0; i < nInserts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tx, err := dbs.BeginTx(context.Background())
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer tx.Rollback()
+			ids[i], errs[i] = insert(tx, i)
+			if errs[i] == nil {
+				errs[i] = tx.Commit()
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return ids
+}
+
+// helper function to check that ids are non-zero and unique
+func checkIds(t *testing.T, table string, ids []int64) {
+	seen := make(map[int64]bool)
+	for _, id := range ids {
+		if id == 0 {
+			t.Errorf("%s: zero id of inserted record", table)
+		}
+		if seen[id] {
+			t.Errorf("%s: duplicate id %d", table, id)
+		}
+		seen[id] = true
+	}
+}
+
+// helper function to check that id of inserted record matches id in DB
+func checkID(t *testing.T, table, id, attr string, val interface{}, expect int64) {
+	tid, err := dbs.QueryRow(table, id, attr, val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tid != expect {
+		t.Errorf("%s: %s=%v has id %d in DB, while insert returned %d", table, attr, val, tid, expect)
+	}
+}
+
+// TestDBSParallelInserts tests that concurrent inserts obtain unique ids
+func TestDBSParallelInserts(t *testing.T) {
+	initDB(t)
+	prefix := fmt.Sprintf("parallel-%d", dbs.Date())
+
+	// sites
+	ids := insertParallel(t, func(tx *dbs.Tx, i int) (int64, error) {
+		rec := dbs.Sites{SITE: fmt.Sprintf("%s-site-%d", prefix, i)}
+		err := rec.Insert(tx)
+		return rec.SITE_ID, err
+	})
+	checkIds(t, "SITES", ids)
+	for i, id := range ids {
+		checkID(t, "SITES", "SITE_ID", "SITE", fmt.Sprintf("%s-site-%d", prefix, i), id)
+	}
+	siteID := ids[0]
+
+	// processing
+	ids = insertParallel(t, func(tx *dbs.Tx, i int) (int64, error) {
+		rec := dbs.Processing{PROCESSING: fmt.Sprintf("%s-processing-%d", prefix, i)}
+		err := rec.Insert(tx)
+		return rec.PROCESSING_ID, err
+	})
+	checkIds(t, "PROCESSING", ids)
+	processingID := ids[0]
+
+	// parents
+	ids = insertParallel(t, func(tx *dbs.Tx, i int) (int64, error) {
+		rec := dbs.Parents{PARENT: fmt.Sprintf("%s-parent-%d", prefix, i)}
+		err := rec.Insert(tx)
+		return rec.PARENT_ID, err
+	})
+	checkIds(t, "PARENTS", ids)
+	parentID := ids[0]
+
+	// datasets
+	ids = insertParallel(t, func(tx *dbs.Tx, i int) (int64, error) {
+		rec := dbs.Datasets{
+			DATASET:          fmt.Sprintf("/%s/dataset/%d", prefix, i),
+			META_ID:          "meta",
+			SITE_ID:          siteID,
+			PROCESSING_ID:    processingID,
+			PARENT_ID:        parentID,
+			CREATE_BY:        "test",
+			LAST_MODIFIED_BY: "test",
+		}
+		err := rec.Insert(tx)
+		return rec.DATASET_ID, err
+	})
+	checkIds(t, "DATASETS", ids)
+	for i, id := range ids {
+		checkID(t, "DATASETS", "DATASET_ID", "DATASET", fmt.Sprintf("/%s/dataset/%d", prefix, i), id)
+	}
+	datasetID := ids[0]
+
+	// files
+	ids = insertParallel(t, func(tx *dbs.Tx, i int) (int64, error) {
+		rec := dbs.Files{
+			LOGICAL_FILE_NAME: fmt.Sprintf("/%s/file-%d.root", prefix, i),
+			IS_FILE_VALID:     1,
+			DATASET_ID:        datasetID,
+			META_ID:           "meta",
+			CREATE_BY:         "test",
+			LAST_MODIFIED_BY:  "test",
+		}
+		err := rec.Insert(tx)
+		return rec.FILE_ID, err
+	})
+	checkIds(t, "FILES", ids)
+	for i, id := range ids {
+		checkID(t, "FILES", "FILE_ID", "LOGICAL_FILE_NAME", fmt.Sprintf("/%s/file-%d.root", prefix, i), id)
+	}
+}
+
+// TestDBSExplicitID tests that explicitly provided id is kept on insert
+func TestDBSExplicitID(t *testing.T) {
+	initDB(t)
+	site := fmt.Sprintf("explicit-%d-site", dbs.Date())
+	tx, err := dbs.BeginTx(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	rec := dbs.Sites{SITE_ID: 1000000 + dbs.Date()%1000000, SITE: site}
+	expect := rec.SITE_ID
+	if err := rec.Insert(tx); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if rec.SITE_ID != expect {
+		t.Errorf("explicit site id %d was changed to %d", expect, rec.SITE_ID)
+	}
+	checkID(t, "SITES", "SITE_ID", "SITE", site, expect)
+}
+
+// TestDBSInitDB tests bootstrap of empty database
+func TestDBSInitDB(t *testing.T) {
+	initDB(t)
+	empty, err := dbs.IsEmptyDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty {
+		t.Error("database is empty after bootstrap")
+	}
+	if err := dbs.GetTestData(context.Background()); err != nil {
+		t.Error(err)
+	}
+	if err := dbs.CheckSchemaVersion(context.Background()); err != nil {
+		t.Error(err)
+	}
+	// bootstrap of non-empty database should fail
+	if err := dbs.InitDB(); err == nil {
+		t.Error("bootstrap of non-empty database should fail")
+	}
+}
@@ -64,7 +64,7 @@ func ProcFSMetrics() ProcFS {
 			var userCpus, sysCpus []float64
 			for _, v := range stats.CPU {
 				userCpus = append(userCpus, v.User)
-				sysCpus = append(sysCpus, v.User)
+				sysCpus = append(sysCpus, v.System)
 			}
 			metrics.UserCPUs = userCpus
 			metrics.SystemCPUs = sysCpus
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+
+	"github.com/OreCast/DataBookkeeping/static"
+)
+
+// STATICFS holds static files embedded into DBS server
+var STATICFS fs.FS = static.FS
+
+// StaticFS returns file system of DBS static files. The files found in
+// optional STATICDIR area on disk override the embedded ones.
+func StaticFS() fs.FS {
+	if STATICDIR == "" {
+		return STATICFS
+	}
+	return overlayFS{top: os.DirFS(STATICDIR), base: STATICFS}
+}
+
+// overlayFS represents file system where files of top file system
+// take precedence over files of base file system
+type overlayFS struct {
+	top  fs.FS
+	base fs.FS
+}
+
+// Open implements fs.FS interface
+func (o overlayFS) Open(name string) (fs.File, error) {
+	if f, err := o.top.Open(name); err == nil {
+		return f, nil
+	}
+	return o.base.Open(name)
+}
+
+// ReadDir implements fs.ReadDirFS interface, it merges entries of both
+// file systems
+func (o overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries := make(map[string]fs.DirEntry)
+	found := false
+	for _, fsys := range []fs.FS{o.base, o.top} {
+		list, err := fs.ReadDir(fsys, name)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		found = true
+		for _, e := range list {
+			entries[e.Name()] = e
+		}
+	}
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	var out []fs.DirEntry
+	for _, e := range entries {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name() < out[j].Name() })
+	return out, nil
+}
+
+// ListStaticFiles lists files in a given directory of DBS static files
+func ListStaticFiles(dir string) ([]string, error) {
+	entries, err := fs.ReadDir(StaticFS(), dir)
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			out = append(out, e.Name())
+		}
+	}
+	return out, nil
+}
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"bytes"
+	"io/fs"
+	"path"
 	"path/filepath"
 	"text/template"
 )
@@ -26,3 +28,23 @@ func ParseTmpl(tdir, tmpl string, data interface{}) (string, error) {
 	}
 	return buf.String(), err
 }
+
+// ParseStaticTmpl parses template from given directory of DBS static files
+// with given data
+func ParseStaticTmpl(tdir, tmpl string, data interface{}) (string, error) {
+	buf := new(bytes.Buffer)
+	t, err := template.ParseFS(StaticFS(), path.Join(tdir, tmpl))
+	if err != nil {
+		return "", err
+	}
+	err = t.Execute(buf, data)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), err
+}
+
+// ReadStaticFile reads given file of DBS static files
+func ReadStaticFile(name string) ([]byte, error) {
+	return fs.ReadFile(StaticFS(), name)
+}
@@ -12,7 +12,6 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
-	"regexp"
 	"runtime"
 	"sort"
 	"strconv"
@@ -30,6 +29,9 @@ var STATICDIR string
 // ORACLE represents a flag that underlying DB is oracle
 var ORACLE bool
 
+// POSTGRES represents a flag that underlying DB is PostgreSQL
+var POSTGRES bool
+
 // BASE represents /base path of dbs2go end-point
 var BASE string
 
@@ -236,14 +238,70 @@ func CastFloat(val interface{}) (float64, error) {
 	return 0, errors.New(msg)
 }
 
-// ReplaceBinds replaces given pattern in string
+// ReplaceBinds replaces named (:name) binds in given statement with
+// question mark binds
 func ReplaceBinds(stm string) string {
-	regexp, err := regexp.Compile(`:[a-zA-Z_0-9]+`)
-	if err != nil {
-		log.Fatal(err)
+	return replaceBinds(stm, func() string { return "?" })
+}
+
+// PositionalBinds replaces named (:name) and question mark (?) binds in
+// given statement with positional ($1, $2, ...) binds used by PostgreSQL
+func PositionalBinds(stm string) string {
+	idx := 0
+	return replaceBinds(stm, func() string {
+		idx += 1
+		return fmt.Sprintf("$%d", idx)
+	})
+}
+
+// helper function to replace named and question mark binds of given
+// statement with binds provided by given function. Quoted literals and
+// identifiers along with PostgreSQL type casts (::type) are kept as is.
+func replaceBinds(stm string, bind func() string) string {
+	var out strings.Builder
+	for i := 0; i < len(stm); i++ {
+		c := stm[i]
+		switch {
+		case c == '\'' || c == '"':
+			// copy quoted text, doubled quote is escaped quote
+			j := i + 1
+			for j < len(stm) {
+				if stm[j] == c {
+					if j+1 < len(stm) && stm[j+1] == c {
+						j += 2
+						continue
+					}
+					break
+				}
+				j++
+			}
+			if j < len(stm) {
+				j++
+			}
+			out.WriteString(stm[i:j])
+			i = j - 1
+		case c == ':' && i+1 < len(stm) && stm[i+1] == ':':
+			out.WriteString("::")
+			i++
+		case c == ':' && i+1 < len(stm) && isBindChar(stm[i+1]):
+			j := i + 1
+			for j < len(stm) && isBindChar(stm[j]) {
+				j++
+			}
+			out.WriteString(bind())
+			i = j - 1
+		case c == '?':
+			out.WriteString(bind())
+		default:
+			out.WriteByte(c)
+		}
 	}
-	match := regexp.ReplaceAllString(stm, "?")
-	return match
+	return out.String()
+}
+
+// helper function to check if given character can be used in bind name
+func isBindChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
 }
 
 // ConvertFloat converts string representation of float scientific number to string int
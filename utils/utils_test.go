@@ -0,0 +1,37 @@
+package utils
+
+import "testing"
+
+// TestPositionalBinds tests conversion of binds into PostgreSQL positional binds
+func TestPositionalBinds(t *testing.T) {
+	tests := []struct {
+		stm    string
+		expect string
+	}{
+		{"SELECT * FROM T WHERE A=:a AND B=?", "SELECT * FROM T WHERE A=$1 AND B=$2"},
+		{"INSERT INTO T (A,B) VALUES (:a,:b_1)", "INSERT INTO T (A,B) VALUES ($1,$2)"},
+		// PostgreSQL type casts are kept
+		{"SELECT A::text FROM T WHERE B=:b::bigint", "SELECT A::text FROM T WHERE B=$1::bigint"},
+		// binds within quoted literals and identifiers are kept
+		{"SELECT * FROM T WHERE A=':a?' AND B=:b", "SELECT * FROM T WHERE A=':a?' AND B=$1"},
+		{"SELECT * FROM T WHERE A='it''s :a' AND B=?", "SELECT * FROM T WHERE A='it''s :a' AND B=$1"},
+		{`SELECT "C:x?" FROM T WHERE A=?`, `SELECT "C:x?" FROM T WHERE A=$1`},
+		{"SELECT * FROM T WHERE A='10:30' AND B=:b", "SELECT * FROM T WHERE A='10:30' AND B=$1"},
+		// unterminated literal is copied as is
+		{"SELECT ':a", "SELECT ':a"},
+	}
+	for _, tt := range tests {
+		if got := PositionalBinds(tt.stm); got != tt.expect {
+			t.Errorf("PositionalBinds(%q) = %q, expect %q", tt.stm, got, tt.expect)
+		}
+	}
+}
+
+// TestReplaceBinds tests conversion of named binds into question mark binds
+func TestReplaceBinds(t *testing.T) {
+	stm := "SELECT A::text FROM T WHERE A=':a' AND B=:b AND C=?"
+	expect := "SELECT A::text FROM T WHERE A=':a' AND B=? AND C=?"
+	if got := ReplaceBinds(stm); got != expect {
+		t.Errorf("ReplaceBinds(%q) = %q, expect %q", stm, got, expect)
+	}
+}